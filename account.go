@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ---------------- /stop ----------------
+// 예약 메시지(월요일 안내, 예약 수업, 월간 리포트)만 멈추고 명령어는 그대로 사용
+func handleStopCommand(bot Messenger, chatID string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	if progress.Stopped {
+		sendToTelegram(bot, chatID, tr(locale, "stop.already"))
+		return
+	}
+
+	progress.Stopped = true
+	saveUserProgress(progress)
+	fmt.Printf("✓ User %s stopped scheduled messages\n", chatID)
+
+	sendToTelegram(bot, chatID, tr(locale, "stop.done"))
+}
+
+// ---------------- /deleteme ----------------
+// 버튼으로 한 번 더 확인한 뒤 삭제
+func handleDeleteMeCommand(bot Messenger, chatID string) {
+	locale := chatLocale(chatID)
+
+	keyboard := [][]InlineButton{{
+		{Text: tr(locale, "deleteme.yes"), CallbackData: "deleteme:yes"},
+		{Text: tr(locale, "deleteme.no"), CallbackData: "deleteme:no"},
+	}}
+	if _, err := sendKeyboard(bot, chatID, tr(locale, "deleteme.confirm"), keyboard); err != nil {
+		fmt.Printf("❌ Error asking delete confirmation to %s: %v\n", chatID, err)
+	}
+}
+
+func handleDeleteMeAnswer(bot Messenger, chatID string, cq CallbackQuery, answer string) {
+	// 삭제하면 언어 설정도 사라지므로 미리 구함
+	locale := chatLocale(chatID)
+	bot.AnswerCallbackQuery(cq.ID, "")
+
+	text := tr(locale, "deleteme.cancelled")
+	if answer == "yes" {
+		if err := eraseUser(chatID); err != nil {
+			return
+		}
+		text = tr(locale, "deleteme.done")
+	}
+
+	if err := editMessage(bot, chatID, cq.Message.MessageID, text, nil); err != nil {
+		fmt.Printf("❌ Error editing delete confirmation for %s: %v\n", chatID, err)
+	}
+}
+
+// 등록 chat ID, 진행도(학습/복습/퀴즈 기록, 설정 포함)를 삭제
+func eraseUser(chatID string) error {
+	if err := store.DeleteUser(chatID); err != nil {
+		fmt.Printf("❌ Error erasing data for %s: %v\n", chatID, err)
+		return err
+	}
+	fmt.Printf("✓ Erased data for %s\n", chatID)
+	return nil
+}
+
+// ---------------- 삭제 요청 처리 (go run . erase) ----------------
+// go run . erase 123 456 또는 go run . erase -file requests.txt (한 줄에 chat ID 하나)
+func runErase(args []string) {
+	fs := flag.NewFlagSet("erase", flag.ExitOnError)
+	file := fs.String("file", "", "삭제할 chat ID 목록 파일 (한 줄에 하나, #은 주석)")
+	fs.Parse(args)
+
+	chatIDs := fs.Args()
+	if *file != "" {
+		ids, err := readChatIDList(*file)
+		if err != nil {
+			fmt.Println("Error reading erase list:", err)
+			os.Exit(1)
+		}
+		chatIDs = append(chatIDs, ids...)
+	}
+	if len(chatIDs) == 0 {
+		fmt.Println("Usage: go run . erase [-file list.txt] <chat_id>...")
+		os.Exit(2)
+	}
+
+	var err error
+	store, err = openStore()
+	if err != nil {
+		fmt.Println("Error opening store:", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	failed := 0
+	for _, chatID := range dedupeChatIDs(chatIDs) {
+		_, found, err := store.LoadUser(chatID)
+		if err == nil && !found && !isChatIDRegistered(chatID) {
+			fmt.Printf("⚠️ No data for %s\n", chatID)
+			continue
+		}
+		if eraseUser(chatID) != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		store.Close()
+		os.Exit(1)
+	}
+}
+
+func readChatIDList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	return ids, scanner.Err()
+}
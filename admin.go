@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ---------------- /admin (운영자 명령어) ----------------
+// ADMIN_CHAT_IDS=123456,789012 에 있는 채팅만 사용 가능 (다른 사용자에게는 알 수 없는 명령어처럼 무시)
+func isAdmin(chatID string) bool {
+	for _, id := range strings.Split(os.Getenv("ADMIN_CHAT_IDS"), ",") {
+		if strings.TrimSpace(id) == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+func handleAdminCommand(bot Messenger, chatID, text string) {
+	if !isAdmin(chatID) {
+		return
+	}
+
+	locale := chatLocale(chatID)
+	// broadcast 메시지는 줄바꿈을 그대로 보내도록 나머지 부분을 자르지 않음
+	args := strings.TrimSpace(strings.TrimPrefix(text, "/admin"))
+	sub := ""
+	if fields := strings.Fields(args); len(fields) > 0 {
+		sub = fields[0]
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(args, sub))
+
+	switch sub {
+	case "users":
+		sendLongMessage(bot, chatID, formatAdminUsers(locale))
+	case "stats":
+		sendToTelegram(bot, chatID, formatAdminStats(locale, time.Now()))
+	case "broadcast":
+		handleAdminBroadcast(bot, chatID, rest, locale)
+	case "reset":
+		handleAdminReset(bot, chatID, rest, locale)
+	default:
+		sendToTelegram(bot, chatID, tr(locale, "admin.usage"))
+	}
+}
+
+// 등록 사용자 한 줄씩 (chat ID, 봇 언어, 학습 단어 수, 마지막 학습, 상태)
+func formatAdminUsers(locale string) string {
+	chatIDs := loadChatIDs()
+	msg := tr(locale, "admin.users_title", "count", len(chatIDs)) + "\n\n"
+	for _, id := range chatIDs {
+		progress := loadUserProgress(id)
+		status := ""
+		if progress.Inactive {
+			status = tr(locale, "admin.status_inactive")
+		} else if progress.Stopped {
+			status = tr(locale, "admin.status_stopped")
+		}
+		msg += tr(locale, "admin.user_line",
+			"id", id,
+			"locale", userLocale(progress),
+			"count", learnedTotal(progress),
+			"last", formatLastStudy(progress.LastStudy, locale),
+			"status", status) + "\n"
+	}
+	return msg
+}
+
+func formatAdminStats(locale string, now time.Time) string {
+	chatIDs := loadChatIDs()
+	weekStart := now.AddDate(0, 0, -6).Format("2006-01-02")
+
+	active, stopped, inactive, scheduled, studiedWeek := 0, 0, 0, 0, 0
+	learnedByLevel := make(map[string]int)
+	for _, id := range chatIDs {
+		progress := loadUserProgress(id)
+		switch {
+		case progress.Inactive:
+			inactive++
+		case progress.Stopped:
+			stopped++
+		default:
+			active++
+		}
+		if progress.Schedule != nil {
+			scheduled++
+		}
+		for day := range progress.Activity {
+			if day >= weekStart {
+				studiedWeek++
+				break
+			}
+		}
+		for level, words := range progress.LearnedWords {
+			learnedByLevel[level] += len(words)
+		}
+	}
+
+	levelLines, learned := "", 0
+	for _, level := range registeredLevels() {
+		levelLines += fmt.Sprintf("%s %s: %d\n", level.Emoji, level.Name, learnedByLevel[level.ID])
+		learned += learnedByLevel[level.ID]
+	}
+
+	return tr(locale, "admin.stats",
+		"total", len(chatIDs),
+		"active", active,
+		"stopped", stopped,
+		"inactive", inactive,
+		"week", studiedWeek,
+		"scheduled", scheduled,
+		"learned", learned,
+		"levels", levelLines)
+}
+
+func learnedTotal(progress UserProgress) int {
+	total := 0
+	for _, words := range progress.LearnedWords {
+		total += len(words)
+	}
+	return total
+}
+
+// 예약 메시지를 받는 모든 사용자에게 전송 (/stop, 비활성 사용자 제외)
+func handleAdminBroadcast(bot Messenger, chatID, text, locale string) {
+	if text == "" {
+		sendToTelegram(bot, chatID, tr(locale, "admin.broadcast_usage"))
+		return
+	}
+
+	succeeded, failed := broadcast(bot, func(UserProgress) string { return text }, nil)
+	fmt.Printf("✓ Admin %s broadcast: %d sent, %d failed\n", chatID, succeeded, failed)
+
+	sendToTelegram(bot, chatID, tr(locale, "admin.broadcast_done", "succeeded", succeeded, "failed", failed))
+}
+
+// 학습 기록만 초기화 (등록, 언어, 예약 수업, /stop 상태는 유지)
+func handleAdminReset(bot Messenger, chatID, target, locale string) {
+	if target == "" {
+		sendToTelegram(bot, chatID, tr(locale, "admin.reset_usage"))
+		return
+	}
+	if !isChatIDRegistered(target) {
+		sendToTelegram(bot, chatID, tr(locale, "admin.reset_unknown", "id", target))
+		return
+	}
+
+	old := loadUserProgress(target)
+	progress := newUserProgress(target)
+	progress.Language = old.Language
+	progress.Locale = old.Locale
+	progress.LanguageCode = old.LanguageCode
+	progress.Timezone = old.Timezone
+	progress.Schedule = old.Schedule
+	progress.LastScheduledLesson = old.LastScheduledLesson
+	progress.WelcomeSent = old.WelcomeSent
+	progress.LastWelcomeDate = old.LastWelcomeDate
+	progress.LastMonthlyReport = old.LastMonthlyReport
+	progress.Inactive = old.Inactive
+	progress.InactiveSince = old.InactiveSince
+	progress.Stopped = old.Stopped
+	saveUserProgress(progress)
+	fmt.Printf("✓ Admin %s reset progress of %s\n", chatID, target)
+
+	sendToTelegram(bot, chatID, tr(locale, "admin.reset_done", "id", target))
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// 구분자 없는 긴 목록도 4,000바이트 이하로 나눠서 순서대로 전부 보냄
+func TestAdminUsersSplitsLongList(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	t.Setenv("ADMIN_CHAT_IDS", "42")
+
+	srv.AddMessage(42, "/start")
+	poll(t, bot)
+	var ids []string
+	for i := 0; i < 150; i++ {
+		ids = append(ids, fmt.Sprintf("%d", 1000000000+i))
+	}
+	mergeChatIDs(ids)
+
+	srv.Reset()
+	srv.AddMessage(42, "/admin users")
+	poll(t, bot)
+
+	replies := srv.SentTo("42")
+	if len(replies) < 2 {
+		t.Fatalf("got %d messages, want the list split into several", len(replies))
+	}
+	var joined strings.Builder
+	for _, m := range replies {
+		if len(m.Text) > maxMessageLength {
+			t.Errorf("message of %d bytes, want at most %d", len(m.Text), maxMessageLength)
+		}
+		joined.WriteString(m.Text)
+	}
+	if got, want := joined.String(), formatAdminUsers(defaultLocale); got != want {
+		t.Errorf("split messages do not add up to the user list")
+	}
+}
+
+func TestAdminCommandsIgnoreOtherChats(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	t.Setenv("ADMIN_CHAT_IDS", "1")
+
+	srv.AddMessage(42, "/start")
+	poll(t, bot)
+	srv.Reset()
+
+	srv.AddMessage(42, "/admin users")
+	srv.AddMessage(42, "/admin broadcast hi")
+	poll(t, bot)
+	if sent := srv.Sent(); len(sent) != 0 {
+		t.Errorf("non-admin /admin was answered: %q", texts(sent))
+	}
+}
+
+func TestSplitLinesKeepsOrder(t *testing.T) {
+	text := strings.Repeat("a", 10) + "\n" + strings.Repeat("ü", 20) + "\n" + "end"
+	chunks := splitLines(text, 16)
+	if strings.Join(chunks, "") != text {
+		t.Fatalf("chunks %q do not add up to the text", chunks)
+	}
+	for _, c := range chunks {
+		if len(c) > 16 || !utf8.ValidString(c) {
+			t.Errorf("chunk %q: %d bytes or broken UTF-8", c, len(c))
+		}
+	}
+}
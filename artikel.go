@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ---------------- /artikel (der/die/das 연습) ----------------
+// 진행 중인 관사 문제
+type ArtikelState struct {
+	MessageID int    `json:"message_id"`
+	Level     string `json:"level"`
+	Word      string `json:"word"` // 관사 포함 표제어 (예: das Haus)
+	Article   string `json:"article"`
+}
+
+var articles = []string{"der", "die", "das"}
+
+// 틀린 명사를 다시 물어볼 확률
+const artikelRetryRate = 0.5
+
+// 관사가 붙은 단수 명사면 관사와 명사를 분리
+// (정답은 gender 값이 아니라 표제어의 관사를 기준으로 함)
+func splitArticle(w Word) (article, noun string, ok bool) {
+	if w.Gender == "Plural" {
+		return "", "", false
+	}
+
+	article, noun, found := strings.Cut(w.German, " ")
+	if !found || noun == "" {
+		return "", "", false
+	}
+
+	article = strings.ToLower(article)
+	for _, a := range articles {
+		if article == a {
+			return article, noun, true
+		}
+	}
+	return "", "", false
+}
+
+// 아직 맞힌 횟수가 틀린 횟수보다 많지 않은 명사
+func isWeakArtikel(score Score) bool {
+	return score.Wrong > 0 && score.Correct <= score.Wrong
+}
+
+func handleArtikelCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	level := "a1"
+	if len(parts) > 1 {
+		level = strings.ToLower(parts[1])
+	}
+
+	if _, ok := levelFilename(level); !ok {
+		sendToTelegram(bot, chatID, tr(chatLocale(chatID), "level.unknown_example", "levels", levelChoices(), "example", "/artikel a1"))
+		return
+	}
+
+	sendArtikel(bot, chatID, level)
+}
+
+func sendArtikel(bot Messenger, chatID, level string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	if _, err := vocab.LevelWords(level); err != nil {
+		sendToTelegram(bot, chatID, tr(locale, "level.words_missing"))
+		return
+	}
+
+	// 단수 명사 (Maskulin/Neutrum처럼 둘 다인 단어는 한 번만)
+	var nouns, weak []Word
+	seen := make(map[string]bool)
+	for _, gender := range []string{"Maskulin", "Feminin", "Neutrum"} {
+		for _, w := range vocab.GenderWords(gender, level) {
+			if _, _, ok := splitArticle(w); !ok || seen[w.German] {
+				continue
+			}
+			seen[w.German] = true
+			nouns = append(nouns, w)
+			if isWeakArtikel(progress.ArtikelStats[w.German]) {
+				weak = append(weak, w)
+			}
+		}
+	}
+
+	if len(nouns) == 0 {
+		sendToTelegram(bot, chatID, tr(locale, "artikel.no_nouns"))
+		return
+	}
+
+	// 자주 틀린 명사를 우선 다시 출제
+	pool := nouns
+	if len(weak) > 0 && rand.Float64() < artikelRetryRate {
+		pool = weak
+	}
+	word := pool[rand.Intn(len(pool))]
+	article, noun, _ := splitArticle(word)
+
+	msg := tr(locale, "artikel.title", "level", levelName(level)) + "\n\n"
+	msg += fmt.Sprintf("*⬜ %s*\n📖 %s\n\n", noun, wordGloss(word, userLanguage(progress)))
+	if isWeakArtikel(progress.ArtikelStats[word.German]) {
+		msg += tr(locale, "artikel.retry") + "\n\n"
+	}
+	msg += tr(locale, "artikel.prompt")
+
+	keyboard := [][]InlineButton{make([]InlineButton, len(articles))}
+	for i, a := range articles {
+		keyboard[0][i] = InlineButton{Text: a, CallbackData: "artikel:" + a}
+	}
+
+	messageID, err := sendKeyboard(bot, chatID, msg, keyboard)
+	if err != nil {
+		fmt.Printf("❌ Error sending artikel drill to %s: %v\n", chatID, err)
+		return
+	}
+
+	progress.CurrentArtikel = &ArtikelState{
+		MessageID: messageID,
+		Level:     level,
+		Word:      word.German,
+		Article:   article,
+	}
+	saveUserProgress(progress)
+}
+
+func handleArtikelAnswer(bot Messenger, chatID string, cq CallbackQuery, value string) {
+	// "다음 문제" 버튼 (artikel:next:<level>)
+	if level, ok := strings.CutPrefix(value, "next:"); ok {
+		bot.AnswerCallbackQuery(cq.ID, "")
+		sendArtikel(bot, chatID, level)
+		return
+	}
+
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	current := progress.CurrentArtikel
+	if current == nil || current.MessageID != cq.Message.MessageID {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "artikel.expired"))
+		return
+	}
+
+	correct := value == current.Article
+
+	if progress.ArtikelStats == nil {
+		progress.ArtikelStats = make(map[string]Score)
+	}
+	score := progress.ArtikelStats[current.Word]
+	if correct {
+		score.Correct++
+	} else {
+		score.Wrong++
+	}
+	progress.ArtikelStats[current.Word] = score
+	progress.CurrentArtikel = nil
+	recordStudy(&progress, time.Now())
+	saveUserProgress(progress)
+
+	fmt.Printf("✓ User %s answered artikel %s: %v\n", chatID, current.Word, correct)
+
+	msg := tr(locale, "artikel.title", "level", levelName(current.Level)) + "\n\n"
+	if correct {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "answer.correct"))
+		msg += tr(locale, "artikel.correct", "word", current.Word) + "\n\n"
+	} else {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "answer.wrong"))
+		msg += tr(locale, "artikel.wrong", "choice", value, "word", current.Word) + "\n\n"
+	}
+	msg += tr(locale, "artikel.score", "correct", score.Correct, "total", score.Correct+score.Wrong)
+
+	next := [][]InlineButton{{{Text: tr(locale, "answer.next"), CallbackData: "artikel:next:" + current.Level}}}
+	if err := editMessage(bot, chatID, cq.Message.MessageID, msg, next); err != nil {
+		fmt.Printf("❌ Error editing artikel drill for %s: %v\n", chatID, err)
+	}
+}
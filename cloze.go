@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ---------------- /cloze (예문 빈칸 채우기) ----------------
+// 진행 중인 빈칸 문제 (사용자가 답을 입력할 때까지 저장)
+type ClozeState struct {
+	Level    string `json:"level"`
+	Word     string `json:"word"`
+	Sentence string `json:"sentence"`
+	Answer   string `json:"answer"` // 예문에 실제로 나온 형태 (예: gehen → gehe)
+}
+
+const clozeBlank = "＿＿＿"
+
+// 표제어에서 관사와 sich를 빼고 가장 긴 단어 (das Haus → Haus, sich erinnern → erinnern)
+func clozeLemma(german string) string {
+	lemma := ""
+	for _, t := range tokenize(german) {
+		switch strings.ToLower(t.text) {
+		case "der", "die", "das", "sich":
+			continue
+		}
+		if utf8.RuneCountInString(t.text) > utf8.RuneCountInString(lemma) {
+			lemma = t.text
+		}
+	}
+	return lemma
+}
+
+// 예문에서 표제어 또는 변화형이 나온 위치 찾기
+// 그대로 나오면 가장 우선, 다음은 어간 + 어미 (geh|e, Haus|e), 다음은 오타 수준 차이
+func findInflectedForm(lemma, sentence string) (token, bool) {
+	l := foldGerman(lemma)
+	if utf8.RuneCountInString(l) < 2 {
+		return token{}, false
+	}
+
+	// 동사는 -en/-n을 뗀 어간도 사용
+	stems := []string{l}
+	if stem, ok := strings.CutSuffix(l, "en"); ok && utf8.RuneCountInString(stem) >= 3 {
+		stems = append(stems, stem)
+	} else if stem, ok := strings.CutSuffix(l, "n"); ok && utf8.RuneCountInString(stem) >= 3 {
+		stems = append(stems, stem)
+	}
+
+	var best token
+	bestScore := 0
+	for _, t := range tokenize(sentence) {
+		f := foldGerman(t.text)
+
+		score := 0
+		switch {
+		case f == l:
+			score = 3
+		case hasInflectedStem(f, stems):
+			score = 2
+		case levenshtein(f, l) <= typoTolerance(l) && strings.HasPrefix(f, firstRunes(l, 3)):
+			score = 1
+		}
+
+		if score > bestScore {
+			best, bestScore = t, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// 어간 뒤에 붙는 어미 (동사 인칭/과거/명령형, 명사 격/복수/여성형, 형용사 격/비교급/최상급)
+// 아무 글자나 허용하면 gehen → gehört 같은 다른 단어도 맞다고 봄
+var inflectionEndings = map[string]bool{
+	"": true, "in": true, "innen": true,
+	"e": true, "em": true, "en": true, "end": true, "ens": true, "er": true, "ern": true, "ers": true, "es": true,
+	"ere": true, "erem": true, "eren": true, "erer": true, "eres": true,
+	"est": true, "et": true, "ete": true, "eten": true, "etest": true, "etet": true,
+	"n": true, "nd": true, "nen": true, "ns": true, "s": true,
+	"st": true, "ste": true, "stem": true, "sten": true, "ster": true, "stes": true,
+	"t": true, "te": true, "tem": true, "ten": true, "ter": true, "tes": true, "test": true, "tet": true,
+}
+
+// 어간 뒤에 어미만 붙은 형태인지 (gehst, Hauses, gute)
+func hasInflectedStem(form string, stems []string) bool {
+	for _, stem := range stems {
+		if ending, ok := strings.CutPrefix(form, stem); ok && inflectionEndings[ending] {
+			return true
+		}
+	}
+	return false
+}
+
+func firstRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// 레벨 단어 중 예문에서 표제어를 찾을 수 있는 문제 하나 만들기
+func newCloze(words []Word, level string) (ClozeState, bool) {
+	for _, i := range rand.Perm(len(words)) {
+		w := words[i]
+		lemma := clozeLemma(w.German)
+
+		for _, j := range rand.Perm(len(w.Examples)) {
+			sentence := w.Examples[j]
+			if t, ok := findInflectedForm(lemma, sentence); ok {
+				return ClozeState{Level: level, Word: w.German, Sentence: sentence, Answer: t.text}, true
+			}
+		}
+	}
+	return ClozeState{}, false
+}
+
+func blankOut(sentence, answer string) string {
+	for _, t := range tokenize(sentence) {
+		if t.text == answer {
+			return sentence[:t.start] + clozeBlank + sentence[t.end:]
+		}
+	}
+	return sentence
+}
+
+func handleClozeCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	level := "a1"
+	if len(parts) > 1 {
+		level = strings.ToLower(parts[1])
+	}
+
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	words, err := vocab.LevelWords(level)
+	if err != nil {
+		sendToTelegram(bot, chatID, tr(locale, "level.unknown_example", "levels", levelChoices(), "example", "/cloze a1"))
+		return
+	}
+
+	cloze, ok := newCloze(words, level)
+	if !ok {
+		sendToTelegram(bot, chatID, tr(locale, "cloze.no_examples"))
+		return
+	}
+
+	progress.CurrentCloze = &cloze
+	saveUserProgress(progress)
+
+	hint := ""
+	if w, ok := vocab.Find(level, cloze.Word); ok {
+		hint = wordGloss(w, userLanguage(progress))
+	}
+
+	msg := tr(locale, "cloze.title", "level", levelName(level)) + "\n\n"
+	msg += fmt.Sprintf("💬 %s\n\n", blankOut(cloze.Sentence, cloze.Answer))
+	msg += tr(locale, "cloze.hint", "hint", hint, "first", firstRunes(cloze.Answer, 1), "count", utf8.RuneCountInString(cloze.Answer)) + "\n\n"
+	msg += tr(locale, "cloze.prompt")
+	sendToTelegram(bot, chatID, msg)
+}
+
+// 빈칸 문제 답 확인 (대소문자, 움라우트 표기, 작은 오타 허용)
+func handleClozeAnswer(bot Messenger, chatID, answer string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	cloze := progress.CurrentCloze
+	if cloze == nil {
+		return
+	}
+
+	skipped := answer == "/skip"
+	correct := !skipped && fuzzyEqual(answer, cloze.Answer)
+
+	if progress.ClozeStats == nil {
+		progress.ClozeStats = make(map[string]Score)
+	}
+	score := progress.ClozeStats[cloze.Level]
+	if correct {
+		score.Correct++
+	} else {
+		score.Wrong++
+	}
+	progress.ClozeStats[cloze.Level] = score
+	progress.CurrentCloze = nil
+	recordStudy(&progress, time.Now())
+	saveUserProgress(progress)
+
+	fmt.Printf("✓ User %s answered cloze %s: %v\n", chatID, cloze.Word, correct)
+
+	msg := ""
+	switch {
+	case correct && foldGerman(answer) == foldGerman(cloze.Answer):
+		msg += tr(locale, "cloze.correct") + "\n\n"
+	case correct:
+		msg += tr(locale, "cloze.correct_spelling", "answer", cloze.Answer) + "\n\n"
+	case skipped:
+		msg += tr(locale, "cloze.skipped", "answer", cloze.Answer) + "\n\n"
+	default:
+		msg += tr(locale, "cloze.wrong", "answer", cloze.Answer) + "\n\n"
+	}
+
+	full := strings.Replace(blankOut(cloze.Sentence, cloze.Answer), clozeBlank, "*"+cloze.Answer+"*", 1)
+	msg += fmt.Sprintf("💬 %s\n", full)
+	if w, ok := vocab.Find(cloze.Level, cloze.Word); ok {
+		msg += fmt.Sprintf("📖 %s — %s\n", w.German, wordGloss(w, userLanguage(progress)))
+	}
+	msg += "\n" + tr(locale, "cloze.next", "level", cloze.Level)
+
+	sendToTelegram(bot, chatID, msg)
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestFindInflectedForm(t *testing.T) {
+	tests := []struct {
+		lemma, sentence string
+		want            string // "" = 찾지 못해야 함
+	}{
+		{"gehen", "Ich gehe nach Hause.", "gehe"},
+		{"gehen", "Du gehst zur Schule.", "gehst"},
+		{"gehen", "Geh nach Hause!", "Geh"},
+		{"Haus", "Das Dach des Hauses ist rot.", "Hauses"},
+		{"gut", "Das ist eine gute Idee.", "gute"},
+		{"arbeiten", "Er arbeitete den ganzen Tag.", "arbeitete"},
+		{"Dichter", "Sie ist eine moderne Dichterin.", "Dichterin"},
+		{"schön", "Das ist schoen.", "schoen"},
+
+		// 어간으로 시작하지만 어미가 아닌 다른 단어
+		{"gehen", "Das gehört mir.", ""},
+		{"fahren", "Er fährt Fahrrad.", ""},
+		{"krank", "Die Krankheit ist ernst.", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := findInflectedForm(tt.lemma, tt.sentence)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("findInflectedForm(%q, %q) = %q, want no match", tt.lemma, tt.sentence, got.text)
+			}
+			continue
+		}
+		if !ok || got.text != tt.want {
+			t.Errorf("findInflectedForm(%q, %q) = %q, %v, want %q", tt.lemma, tt.sentence, got.text, ok, tt.want)
+		}
+	}
+}
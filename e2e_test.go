@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sinramyeon/german-daily-bot/telegramtest"
+)
+
+// 가짜 Bot API 서버와 임시 디렉터리의 JSON 저장소로 봇을 준비
+func newTestBot(t *testing.T) (*telegramtest.Server, *TelegramClient, string) {
+	t.Helper()
+
+	srv := telegramtest.NewServer("TOKEN")
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	store = newJSONStore(dir)
+
+	var err error
+	if vocab, err = loadVocabulary(); err != nil {
+		t.Fatal(err)
+	}
+
+	bot := &TelegramClient{BaseURL: srv.URL, Token: "TOKEN", HTTP: srv.Client()}
+	return srv, bot, dir
+}
+
+func poll(t *testing.T, bot Messenger) {
+	t.Helper()
+	if err := pollUpdates(context.Background(), bot, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartLearnLearnedStats(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+
+	srv.AddMessage(42, "/start")
+	poll(t, bot)
+
+	replies := srv.SentTo("42")
+	if len(replies) != 1 {
+		t.Fatalf("/start: got %d replies, want 1", len(replies))
+	}
+	welcome := tr(defaultLocale, "welcome", "levels", levelCommandLines("   ", "/learn", defaultLocale))
+	if replies[0].Text != welcome {
+		t.Errorf("/start reply = %q, want the welcome message", replies[0].Text)
+	}
+	if !isChatIDRegistered("42") {
+		t.Error("/start did not register chat 42")
+	}
+
+	srv.Reset()
+	srv.AddMessage(42, "/learn a1")
+	poll(t, bot)
+
+	replies = srv.SentTo("42")
+	if len(replies) != 2 {
+		t.Fatalf("/learn a1: got %d replies, want lesson and buttons", len(replies))
+	}
+	title := tr(defaultLocale, "lesson.title", "label", lessonLabel("a1", "", defaultLocale))
+	if !strings.HasPrefix(replies[0].Text, title) {
+		t.Errorf("/learn a1 reply starts with %q, want %q", firstLine(replies[0].Text), title)
+	}
+	if len(replies[1].Keyboard) == 0 {
+		t.Error("/learn a1 sent no word buttons")
+	}
+
+	srv.Reset()
+	srv.AddMessage(42, "/learned das Haus")
+	poll(t, bot)
+
+	progress := loadUserProgress("42")
+	if !isLearned(&progress, "das Haus", "a1") {
+		t.Errorf("/learned das Haus: a1 words = %v", progress.LearnedWords["a1"])
+	}
+	if len(srv.SentTo("42")) == 0 {
+		t.Error("/learned sent no reply")
+	}
+
+	srv.Reset()
+	srv.AddMessage(42, "/stats")
+	poll(t, bot)
+
+	replies = srv.SentTo("42")
+	if len(replies) != 1 || !strings.Contains(replies[0].Text, "A1: 1/") {
+		t.Errorf("/stats replies = %q, want A1 progress 1/…", texts(replies))
+	}
+}
+
+// 기록된 단어가 없으면 학습일(streak, 활동)에 넣지 않음
+func TestLearnedUnknownWordIsNotStudy(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/learned xyz")
+	poll(t, bot)
+
+	progress := loadUserProgress("42")
+	if progress.LastStudy != newUserProgress("42").LastStudy || len(progress.Activity) != 0 {
+		t.Errorf("/learned xyz recorded study: last_study = %q, activity = %v", progress.LastStudy, progress.Activity)
+	}
+
+	srv.AddMessage(42, "/learned das Haus")
+	srv.AddMessage(42, "/learned das Haus")
+	poll(t, bot)
+
+	progress = loadUserProgress("42")
+	if total := progress.Activity[progress.LastStudy]; total != 1 {
+		t.Errorf("activity on %s = %d, want 1 (second /learned adds nothing)", progress.LastStudy, total)
+	}
+}
+
+func TestUnregisteredChatIsIgnored(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+
+	srv.AddMessage(7, "/stats")
+	poll(t, bot)
+
+	if sent := srv.Sent(); len(sent) != 0 {
+		t.Errorf("replied to unregistered chat: %q", texts(sent))
+	}
+}
+
+// 버튼 메시지 전송/수정에서 403이 나도 비활성으로 표시
+func TestBlockedKeyboardMarksInactive(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	poll(t, bot)
+
+	srv.SetBlocked(42, true)
+	srv.AddMessage(42, "/quiz a1")
+	poll(t, bot)
+
+	progress := loadUserProgress("42")
+	if !progress.Inactive || progress.CurrentQuiz != nil {
+		t.Errorf("after blocked quiz: inactive = %v, current quiz = %v", progress.Inactive, progress.CurrentQuiz)
+	}
+}
+
+func TestBlockedEditMarksInactive(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/quiz a1")
+	poll(t, bot)
+
+	quiz := loadUserProgress("42").CurrentQuiz
+	if quiz == nil {
+		t.Fatal("/quiz a1 started no quiz")
+	}
+
+	srv.SetBlocked(42, true)
+	srv.AddCallback(42, quiz.MessageID, "quiz:0")
+	poll(t, bot)
+
+	if !loadUserProgress("42").Inactive {
+		t.Error("403 on editMessageText did not mark the user inactive")
+	}
+}
+
+// 진행도를 먼저 불러와 전송 뒤에 저장하는 명령어도 비활성 표시를 덮어쓰지 않음
+func TestBlockedReviewMarksInactive(t *testing.T) {
+	for _, command := range []string{"/review", "/good"} {
+		t.Run(command, func(t *testing.T) {
+			srv, bot, _ := newTestBot(t)
+			srv.AddMessage(42, "/start")
+			srv.AddMessage(42, "/learned das Haus")
+			poll(t, bot)
+
+			// /good은 복습 중인 카드가 있어야 함
+			progress := loadUserProgress("42")
+			progress.CurrentReview = reviewKey("a1", "das Haus")
+			saveUserProgress(progress)
+
+			srv.SetBlocked(42, true)
+			srv.AddMessage(42, command)
+			poll(t, bot)
+
+			if !loadUserProgress("42").Inactive {
+				t.Errorf("403 on %s did not mark the user inactive", command)
+			}
+		})
+	}
+}
+
+func TestUpdateOffsetIsPersisted(t *testing.T) {
+	srv, bot, dir := newTestBot(t)
+
+	srv.AddMessage(42, "/start")
+	last := srv.AddMessage(42, "/help")
+	poll(t, bot)
+
+	data, err := os.ReadFile(filepath.Join(dir, botStateFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state BotState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatal(err)
+	}
+	if state.LastUpdateID != last {
+		t.Errorf("%s last_update_id = %d, want %d", botStateFile, state.LastUpdateID, last)
+	}
+
+	// 다시 시작해도 저장된 offset부터 이어서 처리
+	store = newJSONStore(dir)
+	srv.Reset()
+	poll(t, bot)
+	if sent := srv.Sent(); len(sent) != 0 {
+		t.Errorf("handled old updates again after restart: %q", texts(sent))
+	}
+
+	srv.AddMessage(42, "/help")
+	poll(t, bot)
+	if replies := srv.SentTo("42"); len(replies) != 1 {
+		t.Errorf("new update after restart: got %d replies, want 1", len(replies))
+	}
+
+	// offset 이하의 업데이트는 다시 와도 처리하지 않음
+	srv.Reset()
+	state = loadBotState()
+	old := Message{Text: "/help"}
+	old.Chat.ID = 42
+	processUpdate(bot, &state, Update{UpdateID: last, Message: old})
+	if sent := srv.Sent(); len(sent) != 0 {
+		t.Errorf("handled update %d below the offset: %q", last, texts(sent))
+	}
+}
+
+// README "로컬 테스트" 예제
+func TestREADMERecipe(t *testing.T) {
+	srv := telegramtest.NewServer("TOKEN")
+	defer srv.Close()
+	store = newJSONStore(t.TempDir())
+	var err error
+	if vocab, err = loadVocabulary(); err != nil {
+		t.Fatal(err)
+	}
+
+	bot := &TelegramClient{BaseURL: srv.URL, Token: "TOKEN", HTTP: srv.Client()}
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/learn a1")
+	pollUpdates(context.Background(), bot, 0)
+
+	// 환영 메시지, 수업, 단어 버튼
+	if replies := srv.SentTo("42"); len(replies) != 3 {
+		t.Errorf("got %d replies, want 3: %q", len(replies), texts(replies))
+	}
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+// 실패 메시지용 (메시지마다 첫 줄만)
+func texts(messages []telegramtest.SentMessage) []string {
+	var result []string
+	for _, m := range messages {
+		result = append(result, firstLine(m.Text))
+	}
+	return result
+}
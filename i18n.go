@@ -0,0 +1,213 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ---------------- 봇 메시지 카탈로그 (ko, en, de) ----------------
+// locales/<언어>.json: 키 → 메시지
+// 메시지는 문자열, 줄 목록(긴 안내문), 또는 복수형 {"one": ..., "other": ...}
+// 메시지 안의 {name} 자리에 tr에 넘긴 값이 들어감
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// 카탈로그에 없는 키는 한국어로 (원래 봇 언어)
+const defaultLocale = "ko"
+
+var supportedLocales = []string{"ko", "en", "de"}
+
+type message struct {
+	Text   string
+	Plural map[string]string // one, other ("count" 값으로 고름)
+}
+
+func (m *message) UnmarshalJSON(data []byte) error {
+	if text, err := decodeMessageText(data); err == nil {
+		m.Text = text
+		return nil
+	}
+
+	var forms map[string]json.RawMessage
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("message must be a string, a list of lines or plural forms")
+	}
+	m.Plural = make(map[string]string, len(forms))
+	for form, raw := range forms {
+		text, err := decodeMessageText(raw)
+		if err != nil {
+			return fmt.Errorf("plural form %q: %w", form, err)
+		}
+		m.Plural[form] = text
+	}
+	if _, ok := m.Plural["other"]; !ok {
+		return fmt.Errorf("plural forms need \"other\"")
+	}
+	return nil
+}
+
+// 문자열 또는 줄 목록
+func decodeMessageText(data []byte) (string, error) {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return text, nil
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// 언어 → 키 → 메시지 (바이너리에 포함되므로 읽기 실패는 빌드 문제)
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]message {
+	catalogs := make(map[string]map[string]message)
+	for _, locale := range supportedLocales {
+		file := path.Join("locales", locale+".json")
+		data, err := localeFiles.ReadFile(file)
+		if err != nil {
+			panic(err)
+		}
+
+		var catalog map[string]message
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("parsing %s: %v", file, err))
+		}
+		catalogs[locale] = catalog
+	}
+	return catalogs
+}
+
+func isSupportedLocale(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// 번역된 메시지 (tr("ko", "review.graded", "word", w, "count", 3))
+// 그 언어에 없는 키는 한국어, 한국어에도 없으면 키 그대로
+func tr(locale, key string, args ...any) string {
+	m, ok := catalogs[locale][key]
+	if !ok {
+		if m, ok = catalogs[defaultLocale][key]; !ok {
+			return key
+		}
+	}
+	return m.format(locale, args)
+}
+
+// 그 언어 카탈로그에 키가 있을 때만 번역 (레벨 설명처럼 원래 값이 따로 있는 경우)
+func trOr(locale, key, fallback string) string {
+	if m, ok := catalogs[locale][key]; ok {
+		return m.format(locale, nil)
+	}
+	return fallback
+}
+
+func (m message) format(locale string, args []any) string {
+	text := m.Text
+	if m.Plural != nil {
+		text = m.Plural["other"]
+	}
+
+	var replacements []string
+	for i := 0; i+1 < len(args); i += 2 {
+		name := fmt.Sprint(args[i])
+		if n, ok := args[i+1].(int); ok && name == "count" && m.Plural != nil {
+			if form, ok := m.Plural[pluralForm(locale, n)]; ok {
+				text = form
+			}
+		}
+		replacements = append(replacements, "{"+name+"}", fmt.Sprint(args[i+1]))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// 복수형 (한국어는 단수/복수 구분 없음)
+func pluralForm(locale string, n int) string {
+	if locale != "ko" && n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// /locale로 고른 언어, 없으면 텔레그램 앱 언어 (en-US → en), 둘 다 없으면 한국어
+func userLocale(progress UserProgress) string {
+	if isSupportedLocale(progress.Locale) {
+		return progress.Locale
+	}
+	base, _, _ := strings.Cut(strings.ToLower(progress.LanguageCode), "-")
+	if isSupportedLocale(base) {
+		return base
+	}
+	return defaultLocale
+}
+
+func chatLocale(chatID string) string {
+	return userLocale(loadUserProgress(chatID))
+}
+
+// 텔레그램이 보내준 앱 언어가 바뀌었으면 저장
+func rememberLanguageCode(chatID, code string) {
+	if code == "" {
+		return
+	}
+	progress := loadUserProgress(chatID)
+	if progress.LanguageCode == code {
+		return
+	}
+	progress.LanguageCode = code
+	saveUserProgress(progress)
+}
+
+// 사용자에게 보여줄 오류 (받는 사람 언어로 번역)
+type userError struct {
+	key  string
+	args []any
+}
+
+func newUserError(key string, args ...any) error {
+	return userError{key: key, args: args}
+}
+
+func (e userError) Error() string {
+	return tr(defaultLocale, e.key, e.args...)
+}
+
+func errorText(locale string, err error) string {
+	var ue userError
+	if errors.As(err, &ue) {
+		return tr(locale, ue.key, ue.args...)
+	}
+	return err.Error()
+}
+
+// ---------------- /locale ----------------
+func handleLocaleCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	if len(parts) < 2 {
+		sendToTelegram(bot, chatID, tr(locale, "locale.current", "locale", tr(locale, "locale.name")))
+		return
+	}
+
+	choice := strings.ToLower(parts[1])
+	if !isSupportedLocale(choice) {
+		sendToTelegram(bot, chatID, tr(locale, "locale.unknown"))
+		return
+	}
+
+	progress.Locale = choice
+	saveUserProgress(progress)
+	fmt.Printf("✓ User %s set locale to %s\n", chatID, choice)
+
+	sendToTelegram(bot, chatID, tr(choice, "locale.saved", "locale", tr(choice, "locale.name")))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// 텔레그램 앱 언어(language_code)로 시작하고, /locale로 고르면 그 언어가 우선
+func TestLocaleFromLanguageCode(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.SetLanguageCode(43, "de-DE")
+	srv.AddMessage(43, "/start")
+	poll(t, bot)
+
+	welcome := tr("de", "welcome", "levels", levelCommandLines("   ", "/learn", "de"))
+	if replies := srv.SentTo("43"); len(replies) != 1 || replies[0].Text != welcome {
+		t.Fatalf("/start with de-DE: got %q, want the de welcome", texts(replies))
+	}
+
+	srv.Reset()
+	srv.AddMessage(43, "/locale en")
+	srv.AddMessage(43, "/learned das Haus")
+	poll(t, bot)
+
+	replies := srv.SentTo("43")
+	if len(replies) != 2 || replies[0].Text != tr("en", "locale.saved", "locale", tr("en", "locale.name")) {
+		t.Fatalf("/locale en: got %q", texts(replies))
+	}
+	if !strings.HasPrefix(replies[1].Text, tr("en", "learned.recorded", "count", 1)) {
+		t.Errorf("/learned after /locale en: got %q, want the en reply", firstLine(replies[1].Text))
+	}
+}
+
+func TestPluralForms(t *testing.T) {
+	tests := []struct {
+		locale string
+		count  int
+		want   string
+	}{
+		{"en", 1, "*1 word*"},
+		{"en", 2, "*2 words*"},
+		{"en", 0, "*0 words*"},
+		{"de", 1, "*1 Wort*"},
+		{"de", 3, "*3 Wörter*"},
+	}
+	for _, tt := range tests {
+		if got := tr(tt.locale, "learned.recorded", "count", tt.count); !strings.Contains(got, tt.want) {
+			t.Errorf("tr(%s, learned.recorded, count=%d) = %q, want %q", tt.locale, tt.count, got, tt.want)
+		}
+	}
+
+	// 한국어는 단수/복수 구분 없이 other
+	if got := pluralForm("ko", 1); got != "other" {
+		t.Errorf("pluralForm(ko, 1) = %q, want other", got)
+	}
+}
+
+// 번역이 없는 키는 한국어, 한국어에도 없으면 키 그대로
+func TestMissingKeyFallsBackToKorean(t *testing.T) {
+	catalogs["ko"]["test.only_ko"] = message{Text: "안녕 {name}"}
+	t.Cleanup(func() { delete(catalogs["ko"], "test.only_ko") })
+
+	if got := tr("de", "test.only_ko", "name", "Anna"); got != "안녕 Anna" {
+		t.Errorf("tr(de, test.only_ko) = %q, want the ko text", got)
+	}
+	if got := tr("en", "test.missing"); got != "test.missing" {
+		t.Errorf("tr(en, test.missing) = %q, want the key", got)
+	}
+}
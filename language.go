@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---------------- 설명 언어 (/lang) ----------------
+// 단어 뜻과 명언 번역을 어떤 언어로 보여줄지 (ko, en, both)
+const (
+	langKorean  = "ko"
+	langEnglish = "en"
+	langBoth    = "both"
+)
+
+// 설정하지 않은 사용자 (한국어 뜻이 없는 단어는 영어만 나오므로 예전과 같음)
+const defaultLanguage = langBoth
+
+func isGlossLanguage(lang string) bool {
+	return lang == langKorean || lang == langEnglish || lang == langBoth
+}
+
+// 설정하지 않았으면 봇 언어가 한국어일 때만 한국어 뜻도 함께
+func userLanguage(progress UserProgress) string {
+	if isGlossLanguage(progress.Language) {
+		return progress.Language
+	}
+	if userLocale(progress) != "ko" {
+		return langEnglish
+	}
+	return defaultLanguage
+}
+
+// 표시 이름 (🇰🇷 한국어)
+func languageLabel(lang, locale string) string {
+	return tr(locale, "language."+lang)
+}
+
+// 한 줄 뜻 (고른 언어의 뜻이 없으면 다른 언어로)
+func gloss(lang, english, korean string) string {
+	switch {
+	case korean == "":
+		return english
+	case english == "" || lang == langKorean:
+		return korean
+	case lang == langEnglish:
+		return english
+	}
+	return english + " · " + korean
+}
+
+func wordGloss(w Word, lang string) string {
+	return gloss(lang, w.English, w.Korean)
+}
+
+// 명언 번역 줄 (🇬🇧, 🇰🇷)
+func formatSentenceTranslation(s WiseSentences, lang string) string {
+	english := fmt.Sprintf("🇬🇧 %s\n", s.English)
+	korean := fmt.Sprintf("🇰🇷 %s\n", s.Korean)
+	switch {
+	case s.Korean == "":
+		return english
+	case s.English == "" || lang == langKorean:
+		return korean
+	case lang == langEnglish:
+		return english
+	}
+	return english + korean
+}
+
+func handleLangCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	if len(parts) < 2 {
+		sendToTelegram(bot, chatID, tr(locale, "lang.current", "language", languageLabel(userLanguage(progress), locale)))
+		return
+	}
+
+	lang := strings.ToLower(parts[1])
+	if !isGlossLanguage(lang) {
+		sendToTelegram(bot, chatID, tr(locale, "lang.unknown"))
+		return
+	}
+
+	progress.Language = lang
+	saveUserProgress(progress)
+	fmt.Printf("✓ User %s set language to %s\n", chatID, lang)
+
+	sendToTelegram(bot, chatID, tr(locale, "lang.saved", "language", languageLabel(lang, locale)))
+}
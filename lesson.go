@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------- /learn 수업 ----------------
+// 마지막으로 보낸 수업 (버튼 메시지 수정, /learned 번호 선택용)
+type LessonState struct {
+	MessageID int      `json:"message_id"`
+	Level     string   `json:"level"`
+	Topic     string   `json:"topic,omitempty"`
+	Words     []string `json:"words"`
+	Levels    []string `json:"levels,omitempty"` // 단어별 레벨 (주제 수업은 레벨이 섞임)
+}
+
+// i번째 단어의 레벨 (예전에 저장된 수업은 Level 하나)
+func (l LessonState) wordLevel(i int) string {
+	if i < len(l.Levels) {
+		return l.Levels[i]
+	}
+	return l.Level
+}
+
+// 수업 단어마다 "아는 단어" 버튼을 단 메시지 전송
+func sendLessonButtons(bot Messenger, chatID, level, topic string, words []Word) {
+	lesson := LessonState{Level: level, Topic: topic}
+	for _, w := range words {
+		lesson.Words = append(lesson.Words, w.German)
+		lesson.Levels = append(lesson.Levels, strings.ToLower(w.Level))
+	}
+
+	progress := loadUserProgress(chatID)
+	text, keyboard := formatLessonButtons(&progress, lesson)
+
+	// 버튼 전송에 실패해도 /learned 번호 선택은 되도록 수업은 저장
+	messageID, err := sendKeyboard(bot, chatID, text, keyboard)
+	if err != nil {
+		fmt.Printf("❌ Error sending lesson buttons to %s: %v\n", chatID, err)
+		// 차단한 사용자면 비활성 표시를 덮어쓰지 않도록 저장하지 않음
+		if isBlockedError(err) {
+			return
+		}
+	}
+	lesson.MessageID = messageID
+
+	progress.CurrentLesson = &lesson
+	saveUserProgress(progress)
+}
+
+// 기록된 단어는 ✅, 아직이면 ⬜ (다 기록하면 "전부 알아요" 버튼 제거)
+func formatLessonButtons(progress *UserProgress, lesson LessonState) (string, [][]InlineButton) {
+	locale := userLocale(*progress)
+	var keyboard [][]InlineButton
+	checked := 0
+	for i, word := range lesson.Words {
+		mark := "⬜"
+		if isLearned(progress, word, lesson.wordLevel(i)) {
+			mark = "✅"
+			checked++
+		}
+		label := fmt.Sprintf("%s %d. %s", mark, i+1, word)
+		keyboard = append(keyboard, []InlineButton{{Text: label, CallbackData: fmt.Sprintf("lesson:%d", i)}})
+	}
+	if checked < len(lesson.Words) {
+		keyboard = append(keyboard, []InlineButton{{Text: tr(locale, "lesson.all_button"), CallbackData: "lesson:all"}})
+	}
+
+	text := tr(locale, "lesson.buttons", "label", lessonLabel(lesson.Level, lesson.Topic, locale), "checked", checked, "total", len(lesson.Words))
+	return text, keyboard
+}
+
+func handleLessonAnswer(bot Messenger, chatID string, cq CallbackQuery, value string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	lesson := progress.CurrentLesson
+	if lesson == nil || lesson.MessageID != cq.Message.MessageID {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "lesson.expired"))
+		return
+	}
+
+	var indices []int
+	if value == "all" {
+		for i := range lesson.Words {
+			indices = append(indices, i)
+		}
+	} else if i, err := strconv.Atoi(value); err == nil && i >= 0 && i < len(lesson.Words) {
+		indices = []int{i}
+	} else {
+		bot.AnswerCallbackQuery(cq.ID, "")
+		return
+	}
+
+	now := time.Now()
+	added := 0
+	for _, i := range indices {
+		if markLearned(&progress, lesson.Words[i], lesson.wordLevel(i), now) {
+			added++
+		}
+	}
+
+	if added == 0 {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "lesson.already_learned"))
+		return
+	}
+
+	recordStudy(&progress, now)
+	saveUserProgress(progress)
+	fmt.Printf("✓ User %s learned %d words from lesson buttons\n", chatID, added)
+
+	bot.AnswerCallbackQuery(cq.ID, tr(locale, "lesson.added", "count", added))
+	text, keyboard := formatLessonButtons(&progress, *lesson)
+	if err := editMessage(bot, chatID, cq.Message.MessageID, text, keyboard); err != nil {
+		fmt.Printf("❌ Error editing lesson buttons for %s: %v\n", chatID, err)
+	}
+}
+
+// 버튼 메시지를 현재 진행도에 맞게 다시 그림 (/learned로 기록한 단어 체크)
+func refreshLessonButtons(bot Messenger, progress *UserProgress, words []string) {
+	lesson := progress.CurrentLesson
+	if lesson == nil || lesson.MessageID == 0 {
+		return
+	}
+
+	inLesson := false
+	for _, w := range words {
+		for _, lw := range lesson.Words {
+			if w == lw {
+				inLesson = true
+			}
+		}
+	}
+	if !inLesson {
+		return
+	}
+
+	text, keyboard := formatLessonButtons(progress, *lesson)
+	if err := editMessage(bot, progress.ChatID, lesson.MessageID, text, keyboard); err != nil {
+		fmt.Printf("❌ Error editing lesson buttons for %s: %v\n", progress.ChatID, err)
+	}
+}
+
+// ---------------- /learned 번호 선택 ----------------
+// "all", "1,3,5", "-2" 처럼 단어 대신 수업 번호로 고른 입력인지
+func isLessonSelection(raw string) bool {
+	if strings.EqualFold(raw, "all") {
+		return true
+	}
+
+	fields := lessonSelectionFields(raw)
+	for _, f := range fields {
+		if _, err := strconv.Atoi(f); err != nil {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+func lessonSelectionFields(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+// 번호로 수업 단어 고르기 (Words의 인덱스)
+// all: 전부, 1,3,5: 그 번호만, -2: 2번만 빼고 전부 (양수/음수 섞으면 오류)
+func selectLessonWords(lesson LessonState, raw string) ([]int, error) {
+	var indices []int
+	if strings.EqualFold(raw, "all") {
+		for i := range lesson.Words {
+			indices = append(indices, i)
+		}
+		return indices, nil
+	}
+
+	include := make(map[int]bool)
+	exclude := make(map[int]bool)
+	for _, f := range lessonSelectionFields(raw) {
+		n, _ := strconv.Atoi(f)
+		i := n
+		if n < 0 {
+			i = -n
+		}
+		if i < 1 || i > len(lesson.Words) {
+			return nil, newUserError("lesson.no_such_number", "number", i, "max", len(lesson.Words))
+		}
+		if n < 0 {
+			exclude[i] = true
+		} else {
+			include[i] = true
+		}
+	}
+	if len(include) > 0 && len(exclude) > 0 {
+		return nil, newUserError("lesson.mixed_selection")
+	}
+
+	for i := range lesson.Words {
+		n := i + 1
+		if include[n] || (len(exclude) > 0 && !exclude[n]) {
+			indices = append(indices, i)
+		}
+	}
+	return indices, nil
+}
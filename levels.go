@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ---------------- 레벨 목록 ----------------
+// vocabulary/levels.json 한 항목 (C1, C2 등은 여기에 추가하고 단어 파일을 넣으면 됨)
+type Level struct {
+	ID          string `json:"id"`          // 명령어와 진행도 키 (a1)
+	Name        string `json:"name"`        // 표시 이름 (A1)
+	Description string `json:"description"` // 도움말 설명 (기초 단어)
+	Emoji       string `json:"emoji"`
+	File        string `json:"file"` // vocabulary/ 기준 단어 파일
+}
+
+const vocabularyDir = "vocabulary"
+const levelsFile = "vocabulary/levels.json"
+
+// 매니페스트를 읽을 수 없을 때 사용하는 기본 레벨
+var defaultLevels = []Level{
+	{ID: "a1", Name: "A1", Description: "기초 단어", Emoji: "🟢", File: "a1_words.json"},
+	{ID: "a2", Name: "A2", Description: "초급 단어", Emoji: "🟡", File: "a2_words.json"},
+	{ID: "b1", Name: "B1", Description: "중급 단어", Emoji: "🔵", File: "b1_words.json"},
+	{ID: "b2", Name: "B2", Description: "중고급 단어", Emoji: "🔴", File: "b2_words.json"},
+}
+
+// 매니페스트를 읽지 못하면 기본 레벨 (이유는 readLevels로 확인)
+func loadLevels() []Level {
+	levels, _ := readLevels()
+	return levels
+}
+
+// 매니페스트를 읽을 수 없거나 비어 있으면 defaultLevels와 함께 이유를 돌려줌
+func readLevels() ([]Level, error) {
+	data, err := os.ReadFile(levelsFile)
+	if err != nil {
+		return defaultLevels, err
+	}
+
+	var levels []Level
+	if err := json.Unmarshal(data, &levels); err != nil {
+		return defaultLevels, fmt.Errorf("parsing %s: %w", levelsFile, err)
+	}
+	if len(levels) == 0 {
+		return defaultLevels, fmt.Errorf("%s has no levels", levelsFile)
+	}
+	for i := range levels {
+		levels[i].ID = strings.ToLower(levels[i].ID)
+		if levels[i].Name == "" {
+			levels[i].Name = strings.ToUpper(levels[i].ID)
+		}
+	}
+	return levels, nil
+}
+
+// 등록된 레벨 (단어장을 읽기 전, 예: validate 에서는 매니페스트 파일에서)
+func registeredLevels() []Level {
+	if vocab != nil {
+		return vocab.Levels
+	}
+	return loadLevels()
+}
+
+func findLevel(id string) (Level, bool) {
+	for _, l := range registeredLevels() {
+		if l.ID == strings.ToLower(id) {
+			return l, true
+		}
+	}
+	return Level{}, false
+}
+
+func levelIDs() []string {
+	levels := registeredLevels()
+	ids := make([]string, len(levels))
+	for i, l := range levels {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+// 표시 이름 (등록되지 않은 레벨은 대문자로)
+func levelName(id string) string {
+	if l, ok := findLevel(id); ok {
+		return l.Name
+	}
+	return strings.ToUpper(id)
+}
+
+// 오류 메시지용 레벨 목록 (a1, a2, b1, b2)
+func levelChoices() string {
+	return strings.Join(levelIDs(), ", ")
+}
+
+// 도움말용 레벨 명령어 목록 (• /learn a1 - 기초 단어 (A1 레벨))
+// 설명은 카탈로그에 level.<id>가 있으면 그 번역, 없으면 levels.json 값
+func levelCommandLines(indent, command, locale string) string {
+	var lines []string
+	for _, l := range registeredLevels() {
+		lines = append(lines, tr(locale, "level.command_line",
+			"indent", indent,
+			"command", command,
+			"id", l.ID,
+			"description", trOr(locale, "level."+l.ID, l.Description),
+			"name", l.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func levelFilename(level string) (string, bool) {
+	l, ok := findLevel(level)
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(vocabularyDir, l.File), true
+}
+
+// 등록된 레벨마다 빈 목록으로 시작
+func newLevelProgress() LevelProgress {
+	progress := LevelProgress{}
+	for _, id := range levelIDs() {
+		progress[id] = []string{}
+	}
+	return progress
+}
+
+// 예전 진행도 파일 정리: 키를 소문자 id로 맞추고 새로 추가된 레벨은 빈 목록으로
+func normalizeLevelProgress(old LevelProgress) LevelProgress {
+	progress := newLevelProgress()
+	for level, words := range old {
+		id := strings.ToLower(level)
+		progress[id] = append(progress[id], words...)
+	}
+	return progress
+}
+
+// 등록된 레벨 순서대로, 목록에서 빠진 레벨(예전 데이터)은 뒤에 정렬해서
+func progressLevels(progress LevelProgress) []string {
+	ids := levelIDs()
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
+
+	var extra []string
+	for id := range progress {
+		if !known[id] {
+			extra = append(extra, id)
+		}
+	}
+	sort.Strings(extra)
+	return append(ids, extra...)
+}
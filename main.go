@@ -1,52 +1,163 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"math/rand"
-	"net/http"
-	"net/url"
 	"os"
-	"path/filepath"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 )
 
 type Word struct {
 	German   string   `json:"german"`
 	English  string   `json:"english"`
+	Korean   string   `json:"korean,omitempty"`
+	Gender   string   `json:"gender"`
 	Level    string   `json:"level"`
 	Examples []string `json:"examples"`
 	Synonyms []string `json:"synonyms"`
 	Antonyms []string `json:"antonyms"`
+	Topics   []string `json:"topics,omitempty"`
 }
 
 type WiseSentences struct {
 	German  string `json:"german"`
 	English string `json:"english"`
+	Korean  string `json:"korean,omitempty"`
 }
 
-type LevelProgress struct {
-	A1 []string `json:"a1"`
-	A2 []string `json:"a2"`
-	B1 []string `json:"b1"`
-	B2 []string `json:"b2"`
-}
+// 레벨 id(a1, b2, c1 ...) → 학습 완료 단어
+type LevelProgress map[string][]string
 
 type UserProgress struct {
 	ChatID          string        `json:"chat_id"`
 	LearnedWords    LevelProgress `json:"learned_words"`
 	LastStudy       string        `json:"last_study_date"`
-	LastUpdateID    int           `json:"last_update_id"`
 	WelcomeSent     bool          `json:"welcome_sent"`
 	LastWelcomeDate string        `json:"last_welcome_date"`
+
+	// 간격 반복 복습 카드 (단어 → 상태), 지금 복습 중인 단어
+	Reviews       map[string]ReviewCard `json:"reviews,omitempty"`
+	CurrentReview string                `json:"current_review,omitempty"`
+
+	// 진행 중인 퀴즈, 레벨별 퀴즈 점수
+	CurrentQuiz *QuizState       `json:"current_quiz,omitempty"`
+	QuizStats   map[string]Score `json:"quiz_stats,omitempty"`
+
+	// 진행 중인 관사 문제, 명사별 관사 정답 기록
+	CurrentArtikel *ArtikelState    `json:"current_artikel,omitempty"`
+	ArtikelStats   map[string]Score `json:"artikel_stats,omitempty"`
+
+	// 진행 중인 빈칸 문제, 레벨별 빈칸 점수
+	CurrentCloze *ClozeState      `json:"current_cloze,omitempty"`
+	ClozeStats   map[string]Score `json:"cloze_stats,omitempty"`
+
+	// 마지막 /learn 수업 (아는 단어 버튼, /learned 번호 선택)
+	CurrentLesson *LessonState `json:"current_lesson,omitempty"`
+
+	// 날짜별 학습 활동 수, 연속 학습 기록
+	Activity map[string]int `json:"activity,omitempty"`
+	Streak   StreakState    `json:"streak"`
+
+	// 날짜별 복습 결과, 마지막으로 월간 리포트를 보낸 달 (2006-01)
+	ReviewLog         map[string]Score `json:"review_log,omitempty"`
+	LastMonthlyReport string           `json:"last_monthly_report,omitempty"`
+
+	// 단어 뜻/명언 번역 언어 (ko, en, both), 비어 있으면 봇 언어에 따라
+	Language string `json:"language,omitempty"`
+
+	// 봇 메시지 언어 (/locale), 텔레그램 앱 언어 (language_code, 예: en-US)
+	Locale       string `json:"locale,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+
+	// 사용자 시간대 (IANA, /schedule로 설정, 예약을 꺼도 유지)
+	// 날짜 경계와 월요일 안내/리포트 시각의 기준
+	Timezone string `json:"timezone,omitempty"`
+
+	// 예약 수업, 마지막으로 예약 수업을 보낸 날 (사용자 시간대 기준)
+	Schedule            *LessonSchedule `json:"schedule,omitempty"`
+	LastScheduledLesson string          `json:"last_scheduled_lesson,omitempty"`
+
+	// 봇을 차단했거나 채팅이 없어진 사용자 (예약 메시지 제외, /start로 해제), 비활성이 된 날
+	Inactive      bool   `json:"inactive,omitempty"`
+	InactiveSince string `json:"inactive_since,omitempty"`
+
+	// /stop으로 예약 메시지를 멈춘 사용자 (/start로 해제)
+	Stopped bool `json:"stopped,omitempty"`
+}
+
+// 맞힌/틀린 횟수
+type Score struct {
+	Correct int `json:"correct"`
+	Wrong   int `json:"wrong"`
+}
+
+// 봇 전체 상태 (getUpdates offset 등)
+type BotState struct {
+	LastUpdateID int `json:"last_update_id"`
+}
+
+type Update struct {
+	UpdateID      int            `json:"update_id"`
+	Message       Message        `json:"message"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+type Message struct {
+	MessageID int `json:"message_id"`
+	Chat      struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	From User   `json:"from"`
+	Text string `json:"text"`
+}
+
+// 메시지/버튼을 보낸 사용자 (language_code는 텔레그램 앱 언어, 없을 수 있음)
+type User struct {
+	ID           int64  `json:"id"`
+	LanguageCode string `json:"language_code,omitempty"`
+}
+
+// 인라인 키보드 버튼을 눌렀을 때 오는 업데이트
+type CallbackQuery struct {
+	ID      string  `json:"id"`
+	From    User    `json:"from"`
+	Message Message `json:"message"`
+	Data    string  `json:"data"`
 }
 
 const chatIDFile = "chat_ids.json"
 const userProgressDir = "user_progress"
+const botStateFile = "bot_state.json"
+
+// serve 모드에서 getUpdates 롱폴링 대기 시간 (초)
+const pollTimeout = 50
 
 func main() {
+	// go run . validate → vocabulary/ 단어 파일 검사 (문제가 있으면 exit 1)
+	// 출력이 JSON일 수 있어서 시작 메시지보다 먼저 처리
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+
 	fmt.Println("Starting German Study Bot - Command Processor...")
+
+	// go run . import → user_progress/ 등 JSON 파일을 SQLite로 이관
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		runImport()
+		return
+	}
+
+	// go run . erase <chat_id>... → 삭제 요청 처리 (관리자용)
+	if len(os.Args) > 1 && os.Args[1] == "erase" {
+		runErase(os.Args[2:])
+		return
+	}
+
 	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
 
 	if botToken == "" {
@@ -54,219 +165,318 @@ func main() {
 		return
 	}
 
-	// 월요일 8am인지 확인하고 환영 메시지 전송
-	sendMondayWelcomeIfNeeded(botToken)
-
-	// 명령어 처리 (/start, /learn, /learned, /stats)
-	processCommands(botToken)
-}
-
-// ---------------- 월요일 환영 메시지 ----------------
-func sendMondayWelcomeIfNeeded(botToken string) {
-	now := time.Now()
+	var err error
+	store, err = openStore()
+	if err != nil {
+		fmt.Println("Error opening store:", err)
+		return
+	}
+	defer store.Close()
 
-	// 월요일이고 시간이 8am인지 확인
-	if now.Weekday() != time.Monday || now.Hour() != 8 {
+	// 단어장은 실행마다 한 번만 읽음
+	vocab, err = loadVocabulary()
+	if err != nil {
+		fmt.Println("Error loading vocabulary:", err)
 		return
 	}
 
-	chatIDs := loadChatIDs()
-	today := now.Format("2006-01-02")
+	bot := NewTelegramClient(botToken)
 
-	welcomeMsg := `🇩🇪 *Weekly German Study Guide* 🇩🇪
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			// 상주 모드 (롱폴링 + 내부 스케줄러)
+			serve(bot)
+			return
+		case "webhook":
+			// 웹훅 서버 모드 (리버스 프록시 뒤에서 실행)
+			serveWebhook(bot)
+			return
+		}
+	}
 
-안녕하세요! 이번 주도 독일어 공부를 시작해볼까요? 😊
+	// 기본: GitHub Actions용 1회 실행
+	// 월요일 8am 이후인지 확인하고 환영 메시지 전송
+	runScheduledJobs(bot)
 
-*📚 사용 가능한 명령어:*
+	// 명령어 처리 (/start, /learn, /learned, /stats)
+	processCommands(bot)
+}
 
-*1. /learn [level]*
-   특정 레벨의 단어 10개를 학습합니다
-   예: /learn a1, /learn a2, /learn b1, /learn b2
+// ---------------- 상주(serve) 모드 ----------------
+func serve(bot Messenger) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-*2. /learned [단어들]*
-   학습 완료한 단어를 기록합니다. 단어 그대로 복사하세요.
-   예: /learned Hallo, der Platz, Danke
+	fmt.Println("✓ Serve mode: long polling getUpdates")
 
-*3. /stats*
-   현재 학습 진행 상황을 확인합니다
+	var lastTick time.Time
+	for ctx.Err() == nil {
+		// 내부 스케줄러: 분이 바뀔 때마다 예약 작업 실행
+		if minute := time.Now().Truncate(time.Minute); minute.After(lastTick) {
+			runScheduledJobs(bot)
+			lastTick = minute
+		}
 
-*4. /help*
-   도움말을 다시 봅니다
+		if err := pollUpdates(ctx, bot, pollTimeout); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			fmt.Println("Error polling updates:", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
 
-*💡 추천 학습 방법:*
-• 매일 /learn 명령어로 새 단어 학습
-• 익힌 단어는 /learned로 기록
-• 주기적으로 /stats로 진행도 확인
+	fmt.Println("✓ Serve mode stopped")
+}
 
-화이팅! 💪`
+// 시간 기반 예약 작업 (1회 실행 모드와 serve 모드 공용)
+func runScheduledJobs(bot Messenger) {
+	now := time.Now()
+	sendMondayWelcomeIfNeeded(bot, now)
+	sendScheduledLessons(bot, now)
+	sendMonthlyReportsIfNeeded(bot, now)
+}
 
-	for _, chatID := range chatIDs {
-		progress := loadUserProgress(chatID)
+// ---------------- 월요일 환영 메시지 ----------------
+func sendMondayWelcomeIfNeeded(bot Messenger, now time.Time) {
+	// 예: /learn a1, /learn a2, /learn b1, /learn b2
+	var examples []string
+	for _, id := range levelIDs() {
+		examples = append(examples, "/learn "+id)
+	}
+
+	broadcast(bot, func(progress UserProgress) string {
+		// 사용자 시간대로 월요일 8am 이후인지 확인
+		// (8시대만 보면 cron(07–19시 UTC) 밖인 시간대는 영영 못 받으므로 그날 첫 실행에 보냄)
+		local := userNow(progress, now)
+		if local.Weekday() != time.Monday || local.Hour() < 8 {
+			return ""
+		}
 
 		// 오늘 이미 환영 메시지를 보냈는지 확인
-		if progress.LastWelcomeDate == today {
-			continue
+		if progress.LastWelcomeDate == local.Format("2006-01-02") {
+			return ""
 		}
+		return tr(userLocale(progress), "weekly_guide", "examples", strings.Join(examples, ", "))
+	}, func(progress UserProgress) {
+		sendPeriodReport(bot, progress, "week", now)
 
-		sendToTelegram(botToken, chatID, welcomeMsg)
-
-		// 환영 메시지 전송 기록
-		progress.LastWelcomeDate = today
+		// 환영 메시지 전송 기록 (보내지 못했으면 기록하지 않고 다음 실행에서 다시 시도)
+		progress.LastWelcomeDate = userNow(progress, now).Format("2006-01-02")
 		saveUserProgress(progress)
+	})
+}
+
+// ---------------- 전체 전송 ----------------
+// 예약 메시지를 받는 사용자마다 message가 만든 메시지를 보내고 보낸 뒤 sent 호출 (nil 가능)
+// message가 빈 문자열을 돌려주면 그 사용자는 건너뜀
+func broadcast(bot Messenger, message func(progress UserProgress) string, sent func(progress UserProgress)) (succeeded, failed int) {
+	for _, chatID := range loadChatIDs() {
+		progress := loadUserProgress(chatID)
+		if !wantsScheduledMessages(progress) {
+			continue
+		}
+
+		text := message(progress)
+		if text == "" {
+			continue
+		}
+		if err := sendToTelegram(bot, chatID, text); err != nil {
+			failed++
+			continue
+		}
+		succeeded++
+		if sent != nil {
+			sent(progress)
+		}
 
 		time.Sleep(100 * time.Millisecond) // Rate limiting
 	}
+	return succeeded, failed
 }
 
 // ---------------- 명령어 처리 ----------------
-func processCommands(botToken string) {
-	chatIDs := loadChatIDs()
-
-	// 모든 사용자의 새 메시지 확인
-	for _, chatID := range chatIDs {
-		processUserCommands(botToken, chatID)
+func processCommands(bot Messenger) {
+	if err := pollUpdates(context.Background(), bot, 0); err != nil {
+		fmt.Println("Error fetching updates:", err)
 	}
-
-	// /start로 새로 등록된 사용자 확인
-	checkNewUsers(botToken)
 }
 
-func processUserCommands(botToken, chatID string) {
-	progress := loadUserProgress(chatID)
-
-	// getUpdates with offset
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/getUpdates?offset=%d&allowed_updates=[\"message\"]",
-		botToken, progress.LastUpdateID+1)
+// 봇 전체 offset으로 getUpdates를 한 번 호출하고 업데이트를 처리
+func pollUpdates(ctx context.Context, bot Messenger, timeout int) error {
+	state := loadBotState()
 
-	resp, err := http.Get(apiURL)
+	updates, err := bot.GetUpdates(ctx, state.LastUpdateID+1, timeout)
 	if err != nil {
-		fmt.Printf("Error fetching updates for %s: %v\n", chatID, err)
-		return
-	}
-	defer resp.Body.Close()
-
-	var result struct {
-		Ok     bool `json:"ok"`
-		Result []struct {
-			UpdateID int `json:"update_id"`
-			Message  struct {
-				Chat struct {
-					ID int64 `json:"id"`
-				} `json:"chat"`
-				Text string `json:"text"`
-			} `json:"message"`
-		} `json:"result"`
+		return err
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		fmt.Printf("Error decoding response for %s: %v\n", chatID, err)
-		return
+	for _, update := range updates {
+		processUpdate(bot, &state, update)
 	}
+	return nil
+}
 
-	if !result.Ok || len(result.Result) == 0 {
+// 아직 처리하지 않은 업데이트면 처리하고 offset 저장 (polling/webhook 공용)
+func processUpdate(bot Messenger, state *BotState, update Update) {
+	if update.UpdateID <= state.LastUpdateID {
 		return
 	}
 
-	// 이 사용자의 메시지만 처리
-	maxUpdateID := progress.LastUpdateID
-	for _, update := range result.Result {
-		if fmt.Sprintf("%d", update.Message.Chat.ID) != chatID {
-			continue
-		}
+	dispatchUpdate(bot, update)
 
-		text := strings.TrimSpace(update.Message.Text)
-
-		if strings.HasPrefix(text, "/learn ") {
-			handleLearnLevelCommand(botToken, chatID, text, update.UpdateID)
-		} else if strings.HasPrefix(text, "/learned ") {
-			handleLearnedCommand(botToken, chatID, text, update.UpdateID)
-		} else if text == "/stats" {
-			handleStatsCommand(botToken, chatID)
-		} else if text == "/help" {
-			handleHelpCommand(botToken, chatID)
-		}
+	// 처리할 때마다 offset 저장 (중간에 죽어도 중복 처리 최소화)
+	state.LastUpdateID = update.UpdateID
+	saveBotState(*state)
+}
 
-		// 최대 Update ID 추적
-		if update.UpdateID > maxUpdateID {
-			maxUpdateID = update.UpdateID
+// 업데이트를 보낸 채팅의 핸들러로 전달
+func dispatchUpdate(bot Messenger, update Update) {
+	if cq := update.CallbackQuery; cq != nil {
+		chatID := fmt.Sprintf("%d", cq.Message.Chat.ID)
+		if isChatIDRegistered(chatID) {
+			rememberLanguageCode(chatID, cq.From.LanguageCode)
+			handleCallbackQuery(bot, chatID, *cq)
 		}
+		return
 	}
 
-	// LastUpdateID만 업데이트 (다른 데이터는 각 핸들러에서 저장함)
-	if maxUpdateID > progress.LastUpdateID {
-		progress = loadUserProgress(chatID) // 최신 데이터 다시 로드
-		progress.LastUpdateID = maxUpdateID
-		saveUserProgress(progress)
-		fmt.Printf("✓ Updated LastUpdateID for %s: %d\n", chatID, maxUpdateID)
-	}
-}
+	chatID := fmt.Sprintf("%d", update.Message.Chat.ID)
+	text := strings.TrimSpace(update.Message.Text)
 
-func checkNewUsers(botToken string) {
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/getUpdates", botToken)
-	resp, err := http.Get(apiURL)
-	if err != nil {
-		fmt.Println("Error checking new users:", err)
+	if text == "/start" {
+		registerUser(bot, chatID, update.Message.From.LanguageCode)
 		return
 	}
-	defer resp.Body.Close()
 
-	var result struct {
-		Result []struct {
-			Message struct {
-				Chat struct {
-					ID int64 `json:"id"`
-				} `json:"chat"`
-				Text string `json:"text"`
-			} `json:"message"`
-		} `json:"result"`
+	if !isChatIDRegistered(chatID) {
+		return
 	}
-	json.NewDecoder(resp.Body).Decode(&result)
-
-	newUsers := []string{}
-	for _, update := range result.Result {
-		if update.Message.Text == "/start" {
-			chatID := fmt.Sprintf("%d", update.Message.Chat.ID)
-			if !isChatIDRegistered(chatID) {
-				newUsers = append(newUsers, chatID)
-
-				// 환영 메시지 전송
-				welcomeMsg := `🇩🇪 *German Study Bot에 오신 것을 환영합니다!* 🇩🇪
-
-안녕하세요! 독일어 학습을 도와드리겠습니다. 😊
-
-*📚 사용 가능한 명령어:*
 
-*1. /learn [level]*
-   특정 레벨의 단어 10개를 학습합니다
-   • /learn a1 - 기초 단어
-   • /learn a2 - 초급 단어
-   • /learn b1 - 중급 단어
-   • /learn b2 - 중고급 단어
+	rememberLanguageCode(chatID, update.Message.From.LanguageCode)
+	handleCommand(bot, chatID, text)
+}
 
-*2. /learned [단어들]*
-   학습 완료한 단어를 기록합니다
-   예: /learned Hallo, Der Supermarkt, Danke
+func handleCommand(bot Messenger, chatID, text string) {
+	if strings.HasPrefix(text, "/learn ") {
+		handleLearnLevelCommand(bot, chatID, text)
+	} else if strings.HasPrefix(text, "/learned ") {
+		handleLearnedCommand(bot, chatID, text)
+	} else if text == "/topics" {
+		handleTopicsCommand(bot, chatID)
+	} else if text == "/stats" {
+		handleStatsCommand(bot, chatID)
+	} else if text == "/streak" {
+		handleStreakCommand(bot, chatID)
+	} else if text == "/report" || strings.HasPrefix(text, "/report ") {
+		handleReportCommand(bot, chatID, text)
+	} else if text == "/schedule" || strings.HasPrefix(text, "/schedule ") {
+		handleScheduleCommand(bot, chatID, text)
+	} else if text == "/lang" || strings.HasPrefix(text, "/lang ") {
+		handleLangCommand(bot, chatID, text)
+	} else if text == "/locale" || strings.HasPrefix(text, "/locale ") {
+		handleLocaleCommand(bot, chatID, text)
+	} else if text == "/stop" {
+		handleStopCommand(bot, chatID)
+	} else if text == "/deleteme" {
+		handleDeleteMeCommand(bot, chatID)
+	} else if text == "/admin" || strings.HasPrefix(text, "/admin ") {
+		handleAdminCommand(bot, chatID, text)
+	} else if text == "/help" {
+		handleHelpCommand(bot, chatID)
+	} else if text == "/review" {
+		handleReviewCommand(bot, chatID)
+	} else if text == "/show" {
+		handleShowCommand(bot, chatID)
+	} else if quality, ok := reviewGrades[text]; ok {
+		handleGradeCommand(bot, chatID, quality)
+	} else if text == "/quiz" || strings.HasPrefix(text, "/quiz ") {
+		handleQuizCommand(bot, chatID, text)
+	} else if text == "/artikel" || strings.HasPrefix(text, "/artikel ") {
+		handleArtikelCommand(bot, chatID, text)
+	} else if text == "/cloze" || strings.HasPrefix(text, "/cloze ") {
+		handleClozeCommand(bot, chatID, text)
+	} else if text == "/skip" || !strings.HasPrefix(text, "/") {
+		// 명령어가 아닌 입력은 진행 중인 빈칸 문제의 답
+		handleClozeAnswer(bot, chatID, text)
+	}
+}
 
-*3. /stats*
-   현재 학습 진행 상황을 확인합니다
+// 인라인 버튼 콜백 (data 형식: "<종류>:<값>")
+func handleCallbackQuery(bot Messenger, chatID string, cq CallbackQuery) {
+	kind, value, _ := strings.Cut(cq.Data, ":")
+
+	switch kind {
+	case "quiz":
+		handleQuizAnswer(bot, chatID, cq, value)
+	case "artikel":
+		handleArtikelAnswer(bot, chatID, cq, value)
+	case "learned":
+		handleLearnedChoice(bot, chatID, cq, value)
+	case "lesson":
+		handleLessonAnswer(bot, chatID, cq, value)
+	case "deleteme":
+		handleDeleteMeAnswer(bot, chatID, cq, value)
+	default:
+		bot.AnswerCallbackQuery(cq.ID, "")
+	}
+}
 
-*4. /help*
-   도움말을 다시 봅니다
+// 새 사용자면 등록하고 환영 메시지 전송 (이미 등록된 경우 false)
+// 비활성이거나 /stop한 사용자는 다시 활성화하고 환영 메시지를 다시 보냄
+// 환영 메시지는 텔레그램 앱 언어(languageCode)로
+func registerUser(bot Messenger, chatID, languageCode string) bool {
+	if !isChatIDRegistered(chatID) {
+		mergeChatIDs([]string{chatID})
+		fmt.Printf("Added new user %s\n", chatID)
+	} else if !reactivateUser(chatID) {
+		return false
+	}
 
-*💡 시작하기:*
-/learn a1 명령어로 첫 단어를 배워보세요!
+	rememberLanguageCode(chatID, languageCode)
 
-매주 월요일 아침 8시에 학습 가이드를 보내드립니다.`
+	locale := chatLocale(chatID)
+	sendToTelegram(bot, chatID, tr(locale, "welcome", "levels", levelCommandLines("   ", "/learn", locale)))
+	return true
+}
 
-				sendToTelegram(botToken, chatID, welcomeMsg)
-			}
-		}
+// 전송이 차단/채팅 없음으로 실패한 사용자를 비활성으로 표시
+// (등록되지 않은 채팅은 진행도를 새로 만들지 않음)
+func deactivateUser(chatID string) {
+	if !isChatIDRegistered(chatID) {
+		return
+	}
+	progress := loadUserProgress(chatID)
+	if progress.Inactive {
+		return
 	}
+	progress.Inactive = true
+	progress.InactiveSince = time.Now().Format("2006-01-02")
+	saveUserProgress(progress)
+	fmt.Printf("⚠️ User %s blocked the bot or left, marked inactive\n", chatID)
+}
 
-	if len(newUsers) > 0 {
-		mergeChatIDs(newUsers)
-		fmt.Printf("Added %d new users\n", len(newUsers))
+// 비활성이거나 /stop한 사용자면 다시 활성화 (이미 활성 사용자면 false)
+func reactivateUser(chatID string) bool {
+	progress := loadUserProgress(chatID)
+	if wantsScheduledMessages(progress) {
+		return false
 	}
+	progress.Inactive = false
+	progress.InactiveSince = ""
+	progress.Stopped = false
+	saveUserProgress(progress)
+	fmt.Printf("✓ Reactivated user %s\n", chatID)
+	return true
+}
+
+// 월요일 안내, 예약 수업, 월간 리포트를 보낼 사용자인지
+func wantsScheduledMessages(progress UserProgress) bool {
+	return !progress.Inactive && !progress.Stopped
 }
 
 func isChatIDRegistered(chatID string) bool {
@@ -279,11 +489,13 @@ func isChatIDRegistered(chatID string) bool {
 	return false
 }
 
-func handleLearnedCommand(botToken, chatID, text string, updateID int) {
+func handleLearnedCommand(bot Messenger, chatID, text string) {
 	// "/learned" 제거하고 나머지 전체 스트링 추출
 	raw := strings.TrimSpace(strings.TrimPrefix(text, "/learned"))
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
 	if raw == "" {
-		sendToTelegram(botToken, chatID, "📝 *사용법*\n\n/learned Hallo, Tschüss, Danke\n\n쉼표(,)로 단어를 구분해서 입력하세요.")
+		sendToTelegram(bot, chatID, tr(locale, "learned.usage"))
 		return
 	}
 
@@ -297,177 +509,251 @@ func handleLearnedCommand(botToken, chatID, text string, updateID int) {
 		}
 	}
 
-	progress := loadUserProgress(chatID)
-	levelMap := buildLevelMap()
+	// 번호로 고른 단어는 수업 레벨로 기록 (같은 단어가 여러 레벨에 있을 수 있음)
+	lessonLevels := make(map[string]string)
 
-	newWordsA1 := []string{}
-	newWordsA2 := []string{}
-	newWordsB1 := []string{}
-	newWordsB2 := []string{}
-	unknownWords := []string{}
+	// /learned all, /learned 1,3,5, /learned -2 → 마지막 /learn 수업의 번호
+	if isLessonSelection(raw) {
+		lesson := progress.CurrentLesson
+		if lesson == nil {
+			sendToTelegram(bot, chatID, tr(locale, "learned.no_lesson"))
+			return
+		}
 
-	a1Map := make(map[string]bool)
-	a2Map := make(map[string]bool)
-	b1Map := make(map[string]bool)
-	b2Map := make(map[string]bool)
+		selected, err := selectLessonWords(*lesson, raw)
+		if err != nil {
+			sendToTelegram(bot, chatID, "⚠️ "+errorText(locale, err))
+			return
+		}
 
-	for _, w := range progress.LearnedWords.A1 {
-		a1Map[w] = true
-	}
-	for _, w := range progress.LearnedWords.A2 {
-		a2Map[w] = true
-	}
-	for _, w := range progress.LearnedWords.B1 {
-		b1Map[w] = true
-	}
-	for _, w := range progress.LearnedWords.B2 {
-		b2Map[w] = true
+		words = nil
+		for _, i := range selected {
+			words = append(words, lesson.Words[i])
+			lessonLevels[lesson.Words[i]] = lesson.wordLevel(i)
+		}
 	}
 
-	for _, word := range words {
-		level, exists := levelMap[word]
-		if !exists {
-			unknownWords = append(unknownWords, word)
-			continue
-		}
+	// 레벨별로 새로 기록한 단어
+	newWords := make(map[string][]string)
+	unknownWords := []string{}
+	now := time.Now()
 
-		switch level {
-		case "A1":
-			if !a1Map[word] {
-				progress.LearnedWords.A1 = append(progress.LearnedWords.A1, word)
-				a1Map[word] = true
-				newWordsA1 = append(newWordsA1, word)
-			}
-		case "A2":
-			if !a2Map[word] {
-				progress.LearnedWords.A2 = append(progress.LearnedWords.A2, word)
-				a2Map[word] = true
-				newWordsA2 = append(newWordsA2, word)
-			}
-		case "B1":
-			if !b1Map[word] {
-				progress.LearnedWords.B1 = append(progress.LearnedWords.B1, word)
-				b1Map[word] = true
-				newWordsB1 = append(newWordsB1, word)
-			}
-		case "B2":
-			if !b2Map[word] {
-				progress.LearnedWords.B2 = append(progress.LearnedWords.B2, word)
-				b2Map[word] = true
-				newWordsB2 = append(newWordsB2, word)
+	// 여러 단어와 일치하는 입력 → 후보 (버튼으로 다시 물어봄)
+	var ambiguous []string
+	candidates := make(map[string][]string)
+
+	for _, input := range words {
+		word, level := input, lessonLevels[input]
+		if _, exists := vocab.LevelOf(word); !exists && level == "" {
+			matches := vocab.Match(input)
+			switch len(matches) {
+			case 0:
+				unknownWords = append(unknownWords, input)
+				continue
+			case 1:
+				word = matches[0]
+			default:
+				ambiguous = append(ambiguous, input)
+				candidates[input] = matches
+				continue
 			}
 		}
+		if level == "" {
+			level, _ = vocab.LevelOf(word)
+		}
+		if markLearned(&progress, word, level, now) {
+			newWords[level] = append(newWords[level], word)
+		}
 	}
 
-	progress.LastStudy = time.Now().Format("2006-01-02")
-	progress.LastUpdateID = updateID // UpdateID도 함께 업데이트
-	saveUserProgress(progress)
+	totalNew, totalLearned := 0, 0
+	var added []string
+	for _, words := range newWords {
+		totalNew += len(words)
+		added = append(added, words...)
+	}
+	for _, words := range progress.LearnedWords {
+		totalLearned += len(words)
+	}
 
-	totalNew := len(newWordsA1) + len(newWordsA2) + len(newWordsB1) + len(newWordsB2)
-	totalLearned := len(progress.LearnedWords.A1) + len(progress.LearnedWords.A2) +
-		len(progress.LearnedWords.B1) + len(progress.LearnedWords.B2)
+	// 새로 기록한 단어가 없으면 (/learned xyz, 이미 배운 단어) 학습일로 치지 않음
+	if totalNew > 0 {
+		recordStudy(&progress, now)
+		saveUserProgress(progress)
+	}
 
-	fmt.Printf("✓ User %s learned %d new words (A1:%d, A2:%d, B1:%d, B2:%d)\n",
-		chatID, totalNew, len(newWordsA1), len(newWordsA2), len(newWordsB1), len(newWordsB2))
+	fmt.Printf("✓ User %s learned %d new words\n", chatID, totalNew)
 
-	msg := fmt.Sprintf("✅ *%d개 단어*를 학습 완료로 기록했어요!\n\n", totalNew)
+	msg := tr(locale, "learned.recorded", "count", totalNew) + "\n\n"
 
-	if len(newWordsA1) > 0 {
-		msg += fmt.Sprintf("🟢 *A1:* %s\n", strings.Join(newWordsA1, ", "))
+	for _, level := range registeredLevels() {
+		if words := newWords[level.ID]; len(words) > 0 {
+			msg += fmt.Sprintf("%s *%s:* %s\n", level.Emoji, level.Name, strings.Join(words, ", "))
+		}
 	}
-	if len(newWordsA2) > 0 {
-		msg += fmt.Sprintf("🟡 *A2:* %s\n", strings.Join(newWordsA2, ", "))
+
+	if len(unknownWords) > 0 {
+		msg += "\n" + tr(locale, "learned.unknown", "words", strings.Join(unknownWords, ", ")) + "\n"
 	}
-	if len(newWordsB1) > 0 {
-		msg += fmt.Sprintf("🔵 *B1:* %s\n", strings.Join(newWordsB1, ", "))
+
+	msg += "\n" + tr(locale, "learned.total", "count", totalLearned) + "\n\n"
+	msg += tr(locale, "learned.cheer")
+
+	sendToTelegram(bot, chatID, msg)
+
+	if totalNew > 0 {
+		refreshLessonButtons(bot, &progress, added)
 	}
-	if len(newWordsB2) > 0 {
-		msg += fmt.Sprintf("🔴 *B2:* %s\n", strings.Join(newWordsB2, ", "))
+
+	for _, input := range ambiguous {
+		askWhichWord(bot, chatID, input, candidates[input], locale)
 	}
+}
 
-	if len(unknownWords) > 0 {
-		msg += fmt.Sprintf("\n⚠️ *미등록 단어:* %s\n", strings.Join(unknownWords, ", "))
+// 한 번에 보여줄 후보 단어 수
+const maxWordCandidates = 6
+
+// 입력이 여러 단어와 일치하면 어떤 단어인지 버튼으로 물어봄
+func askWhichWord(bot Messenger, chatID, input string, matches []string, locale string) {
+	if len(matches) > maxWordCandidates {
+		matches = matches[:maxWordCandidates]
 	}
 
-	msg += fmt.Sprintf("\n📚 *총 학습 완료:* %d개\n\n", totalLearned)
-	msg += "계속 화이팅! 💪"
+	keyboard := make([][]InlineButton, len(matches))
+	for i, w := range matches {
+		keyboard[i] = []InlineButton{{Text: w, CallbackData: "learned:" + w}}
+	}
 
-	sendToTelegram(botToken, chatID, msg)
+	msg := tr(locale, "learned.ambiguous", "input", input)
+	if _, err := sendKeyboard(bot, chatID, msg, keyboard); err != nil {
+		fmt.Printf("❌ Error asking word choice to %s: %v\n", chatID, err)
+	}
 }
 
-func handleLearnLevelCommand(botToken, chatID, text string, updateID int) {
-	parts := strings.Fields(text)
-	if len(parts) < 2 {
-		sendToTelegram(botToken, chatID, "📝 *사용법*\n\n/learn a1\n/learn a2\n/learn b1\n/learn b2\n\n레벨을 선택하세요!")
+// 후보 버튼을 눌렀을 때 그 단어를 학습 완료로 기록
+func handleLearnedChoice(bot Messenger, chatID string, cq CallbackQuery, word string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	level, exists := vocab.LevelOf(word)
+	if !exists {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "learned.unknown_word"))
 		return
 	}
 
-	level := strings.ToLower(parts[1])
-	var filename string
+	now := time.Now()
+	added := markLearned(&progress, word, level, now)
+	if added {
+		recordStudy(&progress, now)
+		saveUserProgress(progress)
+	}
 
-	switch level {
-	case "a1":
-		filename = "vocabulary/a1_words.json"
-	case "a2":
-		filename = "vocabulary/a2_words.json"
-	case "b1":
-		filename = "vocabulary/b1_words.json"
-	case "b2":
-		filename = "vocabulary/b2_words.json"
-	default:
-		sendToTelegram(botToken, chatID, "❌ *지원하는 레벨*\n\na1, a2, b1, b2")
-		return
+	msg := tr(locale, "learned.choice_added", "word", word, "level", levelName(level))
+	if !added {
+		msg = tr(locale, "learned.choice_exists", "word", word, "level", levelName(level))
+	} else {
+		fmt.Printf("✓ User %s learned %s (%s)\n", chatID, word, level)
 	}
 
-	// 해당 레벨 단어 로드
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		sendToTelegram(botToken, chatID, "⚠️ 단어 파일을 찾을 수 없습니다.")
+	bot.AnswerCallbackQuery(cq.ID, "")
+	if err := editMessage(bot, chatID, cq.Message.MessageID, msg, nil); err != nil {
+		fmt.Printf("❌ Error editing word choice for %s: %v\n", chatID, err)
+	}
+}
+
+func isLearned(progress *UserProgress, word, level string) bool {
+	for _, w := range progress.LearnedWords[level] {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// 아직 기록되지 않은 단어면 학습 완료 목록과 복습 카드에 추가
+func markLearned(progress *UserProgress, word, level string, now time.Time) bool {
+	if isLearned(progress, word, level) {
+		return false
+	}
+	if progress.LearnedWords == nil {
+		progress.LearnedWords = LevelProgress{}
+	}
+
+	progress.LearnedWords[level] = append(progress.LearnedWords[level], word)
+	addReviewCard(progress, word, level, now)
+	return true
+}
+
+func handleLearnLevelCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	locale := chatLocale(chatID)
+	if len(parts) < 2 {
+		sendToTelegram(bot, chatID, tr(locale, "learn.usage", "levels", levelCommandLines("", "/learn", locale)))
 		return
 	}
 
-	var allWords []Word
-	if err := json.Unmarshal(data, &allWords); err != nil {
-		sendToTelegram(botToken, chatID, "⚠️ 파일 파싱 오류")
+	// 레벨과 주제는 순서 상관없이 (/learn health b1, /learn b1 health)
+	level, topic := "", ""
+	for _, arg := range parts[1:] {
+		arg = strings.ToLower(arg)
+		if _, ok := levelFilename(arg); ok {
+			level = arg
+		} else if isTopic(arg) {
+			topic = arg
+		} else {
+			sendToTelegram(bot, chatID, tr(locale, "learn.unknown", "levels", levelChoices()))
+			return
+		}
+	}
+	if level == "" && topic == "" {
+		sendToTelegram(bot, chatID, tr(locale, "level.unknown", "levels", levelChoices()))
 		return
 	}
 
-	fmt.Println("✓ Loaded", len(allWords), "words for level : ", level)
+	sendLesson(bot, chatID, level, topic)
+}
 
+// 안 배운 단어 10개로 수업 전송 (/learn, 예약 수업 공용)
+// topic이 있으면 그 주제 단어만 (level이 비어 있으면 모든 레벨에서)
+func sendLesson(bot Messenger, chatID, level, topic string) {
 	// 유저 진행도 로드
 	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
 
-	// 해당 레벨의 학습 완료 단어만 맵으로 변환
-	learnedMap := make(map[string]bool)
-	var learnedList []string
-
-	switch level {
-	case "a1":
-		learnedList = progress.LearnedWords.A1
-	case "a2":
-		learnedList = progress.LearnedWords.A2
-	case "b1":
-		learnedList = progress.LearnedWords.B1
-	case "b2":
-		learnedList = progress.LearnedWords.B2
+	var allWords []Word
+	var err error
+	if topic != "" {
+		allWords = vocab.TopicWords(topic, level)
+	} else {
+		allWords, err = vocab.LevelWords(level)
 	}
+	if err != nil {
+		sendToTelegram(bot, chatID, tr(locale, "level.words_missing"))
+		return
+	}
+
+	fmt.Println("✓ Loaded", len(allWords), "words for lesson : ", lessonLabel(level, topic, defaultLocale))
 
-	for _, w := range learnedList {
-		learnedMap[w] = true
+	// 레벨별 학습 완료 단어를 맵으로 변환
+	learnedMap := make(map[string]bool)
+	for id, words := range progress.LearnedWords {
+		for _, w := range words {
+			learnedMap[id+":"+w] = true
+		}
 	}
 
-	// 안 배운 단어만 필터링
+	// 안 배운 단어만 필터링 (파일에 중복된 단어는 한 번만)
 	var unlearned []Word
 	for _, word := range allWords {
-		if !learnedMap[word.German] {
+		key := strings.ToLower(word.Level) + ":" + word.German
+		if !learnedMap[key] {
 			unlearned = append(unlearned, word)
+			learnedMap[key] = true
 		}
 	}
 
 	if len(unlearned) == 0 {
-		msg := fmt.Sprintf("🎉 *%s 레벨 완료!*\n\n모든 단어를 학습했어요!\n\n", strings.ToUpper(level))
-		msg += "다른 레벨도 도전해보세요! 💪"
-		sendToTelegram(botToken, chatID, msg)
+		sendToTelegram(bot, chatID, tr(locale, "learn.complete", "label", lessonLabel(level, topic, locale)))
 		return
 	}
 
@@ -486,58 +772,61 @@ func handleLearnLevelCommand(botToken, chatID, text string, updateID int) {
 	}
 	selectedWords := unlearned[:count]
 
-	// UpdateID 업데이트 및 저장
-	progress.LastUpdateID = updateID
-	saveUserProgress(progress)
-
 	// 메시지 포맷
-	sentence := selectDailySentence()
-	message := formatLevelMessage(selectedWords, sentence, level)
-	sendLongMessage(botToken, chatID, message)
+	sentence, _ := vocab.RandomSentence()
+	message := formatLevelMessage(selectedWords, sentence, lessonLabel(level, topic, locale), userLanguage(progress), locale)
+	if err := sendLongMessage(bot, chatID, message); err != nil {
+		return
+	}
+	sendLessonButtons(bot, chatID, level, topic, selectedWords)
 }
 
-func formatLevelMessage(words []Word, sentence WiseSentences, level string) string {
-	msg := fmt.Sprintf("🇩🇪 *%s Level Study* 🇩🇪\n\n", strings.ToUpper(level))
+func formatLevelMessage(words []Word, sentence WiseSentences, label, lang, locale string) string {
+	msg := tr(locale, "lesson.title", "label", label) + "\n\n"
 
 	for i, word := range words {
 		msg += fmt.Sprintf("*%d. %s*\n", i+1, word.German)
-		msg += fmt.Sprintf("📖 %s\n\n", word.English)
+		msg += fmt.Sprintf("📖 %s\n\n", wordGloss(word, lang))
 		for _, ex := range word.Examples {
 			msg += fmt.Sprintf("💬 %s\n\n", ex)
 		}
 		if len(word.Synonyms) > 0 {
-			msg += fmt.Sprintf("🔄 Synonyms: %s\n\n", strings.Join(word.Synonyms, ", "))
+			msg += tr(locale, "lesson.synonyms", "words", strings.Join(word.Synonyms, ", ")) + "\n\n"
 		}
 		if len(word.Antonyms) > 0 {
-			msg += fmt.Sprintf("🔀 Antonyms: %s\n\n", strings.Join(word.Antonyms, ", "))
+			msg += tr(locale, "lesson.antonyms", "words", strings.Join(word.Antonyms, ", ")) + "\n\n"
 		}
 		msg += "---\n\n"
 	}
 
-	msg += "💡 *Wise Sentence*\n\n"
-	msg += fmt.Sprintf("🇩🇪 %s\n", sentence.German)
-	msg += fmt.Sprintf("🇬🇧 %s\n\n", sentence.English)
-	msg += "_학습한 단어는 /learned 단어, 단어로 기록하세요_"
+	if sentence.German != "" {
+		msg += tr(locale, "lesson.wise_sentence") + "\n\n"
+		msg += fmt.Sprintf("🇩🇪 %s\n", sentence.German)
+		msg += formatSentenceTranslation(sentence, lang) + "\n"
+	}
+	msg += tr(locale, "lesson.footer")
 
 	return msg
 }
 
-func handleStatsCommand(botToken, chatID string) {
+func handleStatsCommand(bot Messenger, chatID string) {
 	progress := loadUserProgress(chatID)
 
 	// 레벨별 통계 계산
-	a1Total := len(loadWordsByLevel("vocabulary/a1_words.json"))
-	a2Total := len(loadWordsByLevel("vocabulary/a2_words.json"))
-	b1Total := len(loadWordsByLevel("vocabulary/b1_words.json"))
-	b2Total := len(loadWordsByLevel("vocabulary/b2_words.json"))
-	totalWords := a1Total + a2Total + b1Total + b2Total
-
-	a1Learned := len(progress.LearnedWords.A1)
-	a2Learned := len(progress.LearnedWords.A2)
-	b1Learned := len(progress.LearnedWords.B1)
-	b2Learned := len(progress.LearnedWords.B2)
+	totalWords, learned := 0, 0
+	levelLines := ""
+	for _, level := range registeredLevels() {
+		words, _ := vocab.LevelWords(level.ID)
+		total := len(words)
+		count := len(progress.LearnedWords[level.ID])
+		totalWords += total
+		learned += count
+		levelLines += fmt.Sprintf("%s %s: %d/%d (%d%%)\n", level.Emoji, level.Name, count, total, getPercentage(count, total))
+	}
 
-	learned := a1Learned + a2Learned + b1Learned + b2Learned
+	// 예전 학습 단어도 복습 대상에 포함해서 계산 (저장하지 않음)
+	syncReviewCards(&progress, time.Now())
+	dueCount := len(dueReviews(progress, time.Now()))
 
 	remaining := totalWords - learned
 	percentage := 0
@@ -545,82 +834,30 @@ func handleStatsCommand(botToken, chatID string) {
 		percentage = (learned * 100) / totalWords
 	}
 
-	msg := fmt.Sprintf("📊 *학습 통계*\n\n"+
-		"✅ *학습 완료:* %d개\n"+
-		"📝 *남은 단어:* %d개\n"+
-		"📈 *진행도:* %d%%\n\n"+
-		"---\n\n"+
-		"📚 *레벨별 진행도*\n\n"+
-		"🟢 A1: %d/%d (%d%%)\n"+
-		"🟡 A2: %d/%d (%d%%)\n"+
-		"🔵 B1: %d/%d (%d%%)\n"+
-		"🔵 B2: %d/%d (%d%%)\n\n"+
-		"---\n\n"+
-		"📅 *마지막 학습:* %s\n\n"+
-		"계속 화이팅! 💪",
-		learned, remaining, percentage,
-		a1Learned, a1Total, getPercentage(a1Learned, a1Total),
-		a2Learned, a2Total, getPercentage(a2Learned, a2Total),
-		b1Learned, b1Total, getPercentage(b1Learned, b1Total),
-		b2Learned, b2Total, getPercentage(b2Learned, b2Total),
-		progress.LastStudy)
+	locale := userLocale(progress)
+	msg := tr(locale, "stats",
+		"learned", learned,
+		"remaining", remaining,
+		"percent", percentage,
+		"levels", levelLines,
+		"due", dueCount,
+		"streak", formatStreakLine(progress.Streak, userNow(progress, time.Now()), locale),
+		"last", formatLastStudy(progress.LastStudy, locale))
 
-	sendToTelegram(botToken, chatID, msg)
+	sendToTelegram(bot, chatID, msg)
 }
 
-func handleHelpCommand(botToken, chatID string) {
-	helpMsg := `🇩🇪 *German Study Bot 도움말* 🇩🇪
-
-안녕하세요! 독일어 학습 봇 사용법을 안내해드릴게요.
-
-*📚 주요 명령어*
-
-*1. /learn [레벨]*
-특정 레벨의 단어 10개를 학습합니다.
-• /learn a1 - 기초 단어 (A1 레벨)
-• /learn a2 - 초급 단어 (A2 레벨)
-• /learn b1 - 중급 단어 (B1 레벨)
-• /learn b2 - 중고급 단어 (B2 레벨)
-
-*2. /learned [단어들]*
-학습 완료한 단어를 기록합니다.
-쉼표(,)로 구분해서 입력하세요.
-
-예시:
-/learned Hallo, der Park, Danke
-
-💡 Tip: 단어를 정확히 복사하세요!
-(대소문자, 관사 포함)
-
-*3. /stats*
-현재 학습 진행 상황을 확인합니다.
-• 레벨별 진행도
-• 총 학습 완료 개수
-• 남은 단어 수
-
-*4. /help*
-이 도움말을 다시 봅니다.
-
----
-
-*💡 학습 팁*
-
-1️⃣ 매일 꾸준히
-   /learn으로 새 단어를 배우세요
-
-2️⃣ 바로 기록
-   외운 단어는 /learned로 즉시 기록하세요
-
-3️⃣ 진행 확인
-   /stats로 성취감을 느껴보세요
-
----
-
-궁금한 점이 있으시면 언제든지 /help를 입력하세요!
-
-Viel Erfolg! 🎓`
+func handleHelpCommand(bot Messenger, chatID string) {
+	locale := chatLocale(chatID)
+	sendToTelegram(bot, chatID, tr(locale, "help", "levels", levelCommandLines("", "/learn", locale)))
+}
 
-	sendToTelegram(botToken, chatID, helpMsg)
+// 마지막 학습일 (아직 학습 기록이 없으면 "처음")
+func formatLastStudy(day, locale string) string {
+	if _, err := time.Parse("2006-01-02", day); err != nil {
+		return tr(locale, "stats.never")
+	}
+	return day
 }
 
 func getPercentage(learned, total int) int {
@@ -629,195 +866,3 @@ func getPercentage(learned, total int) int {
 	}
 	return (learned * 100) / total
 }
-
-func loadWordsByLevel(filename string) []string {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return []string{}
-	}
-
-	var words []Word
-	if err := json.Unmarshal(data, &words); err != nil {
-		return []string{}
-	}
-
-	result := make([]string, len(words))
-	for i, w := range words {
-		result[i] = w.German
-	}
-	return result
-}
-
-// 모든 단어의 레벨 맵 생성 (단어 -> 레벨)
-func buildLevelMap() map[string]string {
-	levelMap := make(map[string]string)
-
-	// A1
-	a1Data, _ := os.ReadFile("vocabulary/a1_words.json")
-	var a1Words []Word
-	json.Unmarshal(a1Data, &a1Words)
-	for _, w := range a1Words {
-		levelMap[w.German] = "A1"
-	}
-
-	// A2
-	a2Data, _ := os.ReadFile("vocabulary/a2_words.json")
-	var a2Words []Word
-	json.Unmarshal(a2Data, &a2Words)
-	for _, w := range a2Words {
-		levelMap[w.German] = "A2"
-	}
-
-	// B1
-	b1Data, _ := os.ReadFile("vocabulary/b1_words.json")
-	var b1Words []Word
-	json.Unmarshal(b1Data, &b1Words)
-	for _, w := range b1Words {
-		levelMap[w.German] = "B1"
-	}
-
-	// B2
-	b2Data, _ := os.ReadFile("vocabulary/b2_words.json")
-	var b2Words []Word
-	json.Unmarshal(b2Data, &b2Words)
-	for _, w := range b2Words {
-		levelMap[w.German] = "B2"
-	}
-
-	return levelMap
-}
-
-// ---------------- 유저 진행도 관리 ----------------
-func loadUserProgress(chatID string) UserProgress {
-	progressFile := filepath.Join(userProgressDir, chatID+"_progress.json")
-
-	if data, err := os.ReadFile(progressFile); err == nil {
-		var progress UserProgress
-		if err := json.Unmarshal(data, &progress); err == nil {
-			return progress
-		}
-	}
-
-	// 파일이 없으면 새로 생성
-	return UserProgress{
-		ChatID: chatID,
-		LearnedWords: LevelProgress{
-			A1: []string{},
-			A2: []string{},
-			B1: []string{},
-			B2: []string{},
-		},
-		LastStudy:    "처음",
-		LastUpdateID: 0,
-	}
-}
-
-func saveUserProgress(progress UserProgress) {
-	os.MkdirAll(userProgressDir, 0755)
-	progressFile := filepath.Join(userProgressDir, progress.ChatID+"_progress.json")
-
-	data, _ := json.MarshalIndent(progress, "", "  ")
-	if err := os.WriteFile(progressFile, data, 0644); err != nil {
-		fmt.Printf("❌ Error saving progress for %s: %v\n", progress.ChatID, err)
-	} else {
-		fmt.Printf("✓ Saved progress for %s (A1:%d, A2:%d, B1:%d, B2:%d)\n",
-			progress.ChatID,
-			len(progress.LearnedWords.A1),
-			len(progress.LearnedWords.A2),
-			len(progress.LearnedWords.B1),
-			len(progress.LearnedWords.B2))
-	}
-}
-
-// ---------------- chat_ids.json 관리 ----------------
-func loadChatIDs() []string {
-	if _, err := os.Stat(chatIDFile); os.IsNotExist(err) {
-		return []string{}
-	}
-	data, _ := os.ReadFile(chatIDFile)
-	var ids []string
-	json.Unmarshal(data, &ids)
-	return ids
-}
-
-func mergeChatIDs(newIDs []string) {
-	ids := loadChatIDs()
-	idMap := make(map[string]bool)
-	for _, id := range ids {
-		idMap[id] = true
-	}
-	for _, id := range newIDs {
-		if !idMap[id] {
-			ids = append(ids, id)
-		}
-	}
-
-	data, _ := json.Marshal(ids)
-	os.WriteFile(chatIDFile, data, 0644)
-}
-
-// ---------------- 명언 선택 ----------------
-func selectDailySentence() WiseSentences {
-	file, _ := os.ReadFile("vocabulary/sentences.json")
-	var sentences []WiseSentences
-	json.Unmarshal(file, &sentences)
-	rand.Seed(time.Now().UnixNano())
-	return sentences[rand.Intn(len(sentences))]
-}
-
-// ---------------- 텔레그램 전송 ----------------
-func sendToTelegram(botToken, chatID, message string) {
-	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", botToken)
-	data := url.Values{}
-	data.Set("chat_id", chatID)
-	data.Set("text", message)
-	data.Set("parse_mode", "Markdown")
-
-	resp, err := http.PostForm(apiURL, data)
-	if err != nil {
-		fmt.Printf("❌ Error sending message to %s: %v\n", chatID, err)
-		return
-	}
-	defer resp.Body.Close()
-
-	fmt.Printf("✓ Sent message to %s\n", chatID)
-}
-
-// sendLongMessage splits long messages and sends them in parts
-func sendLongMessage(botToken, chatID, message string) {
-	const maxLength = 4000 // Telegram limit is 4096, use 4000 for safety
-
-	if len(message) <= maxLength {
-		sendToTelegram(botToken, chatID, message)
-		return
-	}
-
-	// Split by "---" separator (word boundaries)
-	parts := strings.Split(message, "---\n\n")
-
-	currentMsg := ""
-	for i, part := range parts {
-		// Add back the separator except for the last part
-		testMsg := currentMsg + part
-		if i < len(parts)-1 {
-			testMsg += "---\n\n"
-		}
-
-		if len(testMsg) > maxLength && currentMsg != "" {
-			// Send current message and start new one
-			sendToTelegram(botToken, chatID, currentMsg)
-			time.Sleep(200 * time.Millisecond) // Rate limiting
-			currentMsg = part
-			if i < len(parts)-1 {
-				currentMsg += "---\n\n"
-			}
-		} else {
-			currentMsg = testMsg
-		}
-	}
-
-	// Send remaining message
-	if currentMsg != "" {
-		sendToTelegram(botToken, chatID, currentMsg)
-	}
-}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ---------------- 독일어 비교용 정규화 ----------------
+var umlautReplacer = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss",
+)
+
+// 소문자로 바꾸고 움라우트/ß를 풀어쓰기 (Größe → groesse)
+func foldGerman(s string) string {
+	return umlautReplacer.Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// 편집 거리 (삽입/삭제/치환)
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// 단어 길이에 따라 허용하는 오타 수
+func typoTolerance(s string) int {
+	switch n := utf8.RuneCountInString(s); {
+	case n <= 3:
+		return 0
+	case n <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// 오타 허용 비교 (대소문자, 움라우트 표기 무시)
+func fuzzyEqual(input, target string) bool {
+	a, b := foldGerman(input), foldGerman(target)
+	return levenshtein(a, b) <= typoTolerance(b)
+}
+
+// 문장 속 단어 위치 (바이트 오프셋)
+type token struct {
+	text       string
+	start, end int
+}
+
+func tokenize(sentence string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range sentence {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{sentence[start:i], start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{sentence[start:], start, len(sentence)})
+	}
+	return tokens
+}
+
+// ---------------- /learned 단어 매칭 ----------------
+// 표제어 앞에 붙어도 되고 생략해도 되는 관사
+var optionalArticles = map[string]bool{"der": true, "die": true, "das": true}
+
+// 비교용 키: 소문자, 움라우트 풀어쓰기, 앞의 관사 제거 (Das Haus → haus)
+func matchKey(s string) string {
+	fields := strings.Fields(foldGerman(s))
+	if len(fields) > 1 && optionalArticles[fields[0]] {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// 입력과 일치하는 표제어 후보 (정렬됨)
+// 관사까지 같은 단어 > 관사를 빼고 같은 단어 > 오타 허용 범위에서 가장 가까운 단어 순으로 찾음
+func matchWords(input string, words []string) []string {
+	folded := strings.Join(strings.Fields(foldGerman(input)), " ")
+	key := matchKey(input)
+	if key == "" {
+		return nil
+	}
+
+	var exact, sameKey, fuzzy []string
+	bestDistance := 0
+	for _, w := range words {
+		wordKey := matchKey(w)
+		switch {
+		case foldGerman(w) == folded:
+			exact = append(exact, w)
+		case wordKey == key:
+			sameKey = append(sameKey, w)
+		default:
+			d := levenshtein(key, wordKey)
+			if d > typoTolerance(wordKey) {
+				continue
+			}
+			if len(fuzzy) == 0 || d < bestDistance {
+				fuzzy, bestDistance = []string{w}, d
+			} else if d == bestDistance {
+				fuzzy = append(fuzzy, w)
+			}
+		}
+	}
+
+	matches := fuzzy
+	if len(exact) > 0 {
+		matches = exact
+	} else if len(sameKey) > 0 {
+		matches = sameKey
+	}
+	sort.Strings(matches)
+	return matches
+}
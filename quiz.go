@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------- /quiz (인라인 키보드 4지선다) ----------------
+// 진행 중인 퀴즈 (버튼 콜백이 올 때까지 저장)
+type QuizState struct {
+	MessageID int      `json:"message_id"`
+	Level     string   `json:"level"`
+	Word      string   `json:"word"`
+	Options   []string `json:"options"`
+	Answer    int      `json:"answer"`
+}
+
+const quizOptions = 4
+
+func handleQuizCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	level := "a1"
+	if len(parts) > 1 {
+		level = strings.ToLower(parts[1])
+	}
+
+	if _, ok := levelFilename(level); !ok {
+		sendToTelegram(bot, chatID, tr(chatLocale(chatID), "level.unknown_example", "levels", levelChoices(), "example", "/quiz a1"))
+		return
+	}
+
+	sendQuiz(bot, chatID, level)
+}
+
+func sendQuiz(bot Messenger, chatID, level string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	words, err := vocab.LevelWords(level)
+	if err != nil {
+		sendToTelegram(bot, chatID, tr(locale, "level.words_missing"))
+		return
+	}
+
+	quiz, ok := newQuiz(words, level, userLanguage(progress))
+	if !ok {
+		sendToTelegram(bot, chatID, tr(locale, "quiz.not_enough"))
+		return
+	}
+
+	keyboard := make([][]InlineButton, len(quiz.Options))
+	for i, option := range quiz.Options {
+		keyboard[i] = []InlineButton{{Text: option, CallbackData: fmt.Sprintf("quiz:%d", i)}}
+	}
+
+	messageID, err := sendKeyboard(bot, chatID, formatQuizQuestion(quiz, locale), keyboard)
+	if err != nil {
+		fmt.Printf("❌ Error sending quiz to %s: %v\n", chatID, err)
+		return
+	}
+	quiz.MessageID = messageID
+
+	progress.CurrentQuiz = &quiz
+	saveUserProgress(progress)
+}
+
+// 같은 레벨에서 정답 1개 + 뜻이 다른 오답 3개 (뜻은 사용자 설명 언어로)
+func newQuiz(words []Word, level, lang string) (QuizState, bool) {
+	order := rand.Perm(len(words))
+	if len(order) == 0 {
+		return QuizState{}, false
+	}
+
+	answer := words[order[0]]
+	correct := wordGloss(answer, lang)
+	options := []string{correct}
+	seen := map[string]bool{correct: true}
+
+	for _, i := range order[1:] {
+		if len(options) == quizOptions {
+			break
+		}
+		if option := wordGloss(words[i], lang); option != "" && !seen[option] {
+			options = append(options, option)
+			seen[option] = true
+		}
+	}
+	if len(options) < quizOptions {
+		return QuizState{}, false
+	}
+
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+
+	quiz := QuizState{Level: level, Word: answer.German, Options: options}
+	for i, option := range options {
+		if option == correct {
+			quiz.Answer = i
+		}
+	}
+	return quiz, true
+}
+
+func formatQuizQuestion(quiz QuizState, locale string) string {
+	msg := tr(locale, "quiz.title", "level", levelName(quiz.Level)) + "\n\n"
+	msg += fmt.Sprintf("*%s*\n\n", quiz.Word)
+	msg += tr(locale, "quiz.prompt")
+	return msg
+}
+
+func handleQuizAnswer(bot Messenger, chatID string, cq CallbackQuery, value string) {
+	// "다음 문제" 버튼 (quiz:next:<level>)
+	if level, ok := strings.CutPrefix(value, "next:"); ok {
+		bot.AnswerCallbackQuery(cq.ID, "")
+		sendQuiz(bot, chatID, level)
+		return
+	}
+
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	quiz := progress.CurrentQuiz
+	choice, err := strconv.Atoi(value)
+	if quiz == nil || quiz.MessageID != cq.Message.MessageID || err != nil || choice < 0 || choice >= len(quiz.Options) {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "quiz.expired"))
+		return
+	}
+
+	correct := choice == quiz.Answer
+
+	if progress.QuizStats == nil {
+		progress.QuizStats = make(map[string]Score)
+	}
+	score := progress.QuizStats[quiz.Level]
+	if correct {
+		score.Correct++
+	} else {
+		score.Wrong++
+	}
+	progress.QuizStats[quiz.Level] = score
+	progress.CurrentQuiz = nil
+	recordStudy(&progress, time.Now())
+	saveUserProgress(progress)
+
+	fmt.Printf("✓ User %s answered quiz %s: %v\n", chatID, quiz.Word, correct)
+
+	msg := tr(locale, "quiz.title", "level", levelName(quiz.Level)) + "\n\n"
+	msg += fmt.Sprintf("*%s*\n\n", quiz.Word)
+	if correct {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "answer.correct"))
+		msg += tr(locale, "quiz.correct", "answer", quiz.Options[quiz.Answer]) + "\n\n"
+	} else {
+		bot.AnswerCallbackQuery(cq.ID, tr(locale, "answer.wrong"))
+		msg += tr(locale, "quiz.wrong", "choice", quiz.Options[choice]) + "\n"
+		msg += tr(locale, "quiz.answer", "answer", quiz.Options[quiz.Answer]) + "\n\n"
+	}
+	msg += tr(locale, "quiz.score", "level", levelName(quiz.Level), "correct", score.Correct, "total", score.Correct+score.Wrong)
+
+	next := [][]InlineButton{{{Text: tr(locale, "answer.next"), CallbackData: "quiz:next:" + quiz.Level}}}
+	if err := editMessage(bot, chatID, cq.Message.MessageID, msg, next); err != nil {
+		fmt.Printf("❌ Error editing quiz for %s: %v\n", chatID, err)
+	}
+}
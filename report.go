@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ---------------- 주간/월간 리포트 ----------------
+// 기간 동안의 학습 기록 요약
+type periodStats struct {
+	Learned      map[string]int // 레벨 → 새로 배운 단어 수
+	LearnedTotal int
+	Reviews      Score
+	Activity     int // 학습 활동 수 (/learned, 복습, 퀴즈 등)
+	ActiveDays   int
+	BusiestDay   string
+	BusiestCount int
+}
+
+// kind(week/month) 기간의 [start, end), back = 0이면 이번 기간, 1이면 지난 기간
+func periodBounds(kind string, local time.Time, back int) (time.Time, time.Time) {
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+
+	if kind == "month" {
+		start := time.Date(day.Year(), day.Month()-time.Month(back), 1, 0, 0, 0, 0, day.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+
+	// 주는 월요일부터
+	monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	start := monday.AddDate(0, 0, -7*back)
+	return start, start.AddDate(0, 0, 7)
+}
+
+func collectPeriodStats(progress UserProgress, start, end time.Time) periodStats {
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	inPeriod := func(day string) bool {
+		return day >= from && day < to
+	}
+
+	stats := periodStats{Learned: make(map[string]int)}
+	for _, card := range progress.Reviews {
+		if inPeriod(card.Added) {
+			stats.Learned[card.Level]++
+			stats.LearnedTotal++
+		}
+	}
+
+	for day, score := range progress.ReviewLog {
+		if inPeriod(day) {
+			stats.Reviews.Correct += score.Correct
+			stats.Reviews.Wrong += score.Wrong
+		}
+	}
+
+	for day, count := range progress.Activity {
+		if !inPeriod(day) || count == 0 {
+			continue
+		}
+		stats.Activity += count
+		stats.ActiveDays++
+		if count > stats.BusiestCount || (count == stats.BusiestCount && day < stats.BusiestDay) {
+			stats.BusiestDay, stats.BusiestCount = day, count
+		}
+	}
+	return stats
+}
+
+// 지난 기간 대비 증감 (▲3, ▼2, ±0)
+func formatDelta(cur, prev int) string {
+	switch {
+	case cur > prev:
+		return fmt.Sprintf("▲%d", cur-prev)
+	case cur < prev:
+		return fmt.Sprintf("▼%d", prev-cur)
+	}
+	return "±0"
+}
+
+func formatReport(progress UserProgress, kind string, start, end time.Time, cur, prev periodStats, now time.Time) string {
+	locale := userLocale(progress)
+	title, prevLabel := tr(locale, "report."+kind), tr(locale, "report.prev_"+kind)
+	days := int(end.Sub(start).Hours()/24 + 0.5)
+
+	msg := tr(locale, "report.header", "title", title, "from", start.Format("01/02"), "to", end.AddDate(0, 0, -1).Format("01/02")) + "\n\n"
+
+	msg += tr(locale, "report.learned", "count", cur.LearnedTotal, "prev", prevLabel,
+		"prev_count", prev.LearnedTotal, "delta", formatDelta(cur.LearnedTotal, prev.LearnedTotal)) + "\n"
+	var levels []string
+	for _, l := range registeredLevels() {
+		if n := cur.Learned[l.ID]; n > 0 {
+			levels = append(levels, fmt.Sprintf("%s %s %d", l.Emoji, l.Name, n))
+		}
+	}
+	if len(levels) > 0 {
+		msg += strings.Join(levels, " · ") + "\n"
+	}
+
+	reviews := cur.Reviews.Correct + cur.Reviews.Wrong
+	msg += "\n" + tr(locale, "report.reviews", "count", reviews)
+	if reviews > 0 {
+		msg += tr(locale, "report.accuracy", "percent", getPercentage(cur.Reviews.Correct, reviews))
+	}
+	if prevReviews := prev.Reviews.Correct + prev.Reviews.Wrong; prevReviews > 0 {
+		msg += tr(locale, "report.prev_accuracy", "prev", prevLabel, "percent", getPercentage(prev.Reviews.Correct, prevReviews))
+	}
+	msg += "\n"
+
+	msg += tr(locale, "report.days", "count", cur.ActiveDays, "days", days, "prev", prevLabel, "prev_count", prev.ActiveDays) + "\n"
+	if cur.BusiestDay != "" {
+		if day, err := time.Parse("2006-01-02", cur.BusiestDay); err == nil {
+			msg += tr(locale, "report.busiest", "date", day.Format("01/02"),
+				"weekday", weekdayLabel(weekdayNames[day.Weekday()], locale), "count", cur.BusiestCount) + "\n"
+		}
+	}
+	msg += "\n" + formatStreakLine(progress.Streak, now, locale) + "\n\n"
+
+	switch {
+	case cur.Activity == 0:
+		msg += tr(locale, "report.rested")
+	case cur.Activity >= prev.Activity:
+		msg += tr(locale, "report.better")
+	default:
+		msg += tr(locale, "report.keep_going")
+	}
+	return msg
+}
+
+// back = 0: 이번 기간(오늘까지), 1: 지난 기간 전체
+func buildReport(progress UserProgress, kind string, now time.Time, back int) string {
+	local := userNow(progress, now)
+	start, end := periodBounds(kind, local, back)
+	prevStart, prevEnd := periodBounds(kind, local, back+1)
+
+	cur := collectPeriodStats(progress, start, end)
+	prev := collectPeriodStats(progress, prevStart, prevEnd)
+	return formatReport(progress, kind, start, end, cur, prev, local)
+}
+
+// ---------------- /report ----------------
+func handleReportCommand(bot Messenger, chatID, text string) {
+	parts := strings.Fields(text)
+	kind := "week"
+	if len(parts) > 1 {
+		kind = strings.ToLower(parts[1])
+	}
+	progress := loadUserProgress(chatID)
+	if kind != "week" && kind != "month" {
+		sendToTelegram(bot, chatID, tr(userLocale(progress), "report.usage"))
+		return
+	}
+
+	sendToTelegram(bot, chatID, buildReport(progress, kind, time.Now(), 0))
+}
+
+// 지난 기간의 리포트 전송 (지난 두 기간 모두 학습 기록이 없으면 생략)
+func sendPeriodReport(bot Messenger, progress UserProgress, kind string, now time.Time) {
+	local := userNow(progress, now)
+	start, _ := periodBounds(kind, local, 2)
+	_, end := periodBounds(kind, local, 1)
+	if collectPeriodStats(progress, start, end).Activity == 0 {
+		return
+	}
+
+	sendToTelegram(bot, progress.ChatID, buildReport(progress, kind, now, 1))
+}
+
+// 매달 1일 8am(사용자 시간대) 이후 첫 실행에 지난달 리포트 전송
+func sendMonthlyReportsIfNeeded(bot Messenger, now time.Time) {
+	for _, chatID := range loadChatIDs() {
+		progress := loadUserProgress(chatID)
+		if !wantsScheduledMessages(progress) {
+			continue
+		}
+
+		local := userNow(progress, now)
+		if local.Day() != 1 || local.Hour() < 8 {
+			continue
+		}
+
+		month := local.Format("2006-01")
+		if progress.LastMonthlyReport == month {
+			continue
+		}
+
+		progress.LastMonthlyReport = month
+		saveUserProgress(progress)
+		sendPeriodReport(bot, progress, "month", now)
+
+		time.Sleep(100 * time.Millisecond) // Rate limiting
+	}
+}
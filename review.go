@@ -0,0 +1,264 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ---------------- 간격 반복 (SM-2) ----------------
+// 단어별 복습 상태 (키는 "레벨:단어", 여러 레벨에 있는 단어는 레벨마다 따로)
+type ReviewCard struct {
+	Level      string  `json:"level"`
+	Due        string  `json:"due"`      // 다음 복습일 (2006-01-02)
+	Interval   int     `json:"interval"` // 일 단위
+	Ease       float64 `json:"ease"`
+	Reps       int     `json:"reps"` // 연속 성공 횟수
+	Lapses     int     `json:"lapses"`
+	LastReview string  `json:"last_review,omitempty"`
+	Added      string  `json:"added,omitempty"` // 학습 완료로 기록한 날 (리포트용)
+}
+
+const defaultEase = 2.5
+const minEase = 1.3
+
+// 평가 명령어 → SM-2 quality (0~5)
+var reviewGrades = map[string]int{
+	"/again": 1,
+	"/hard":  3,
+	"/good":  4,
+	"/easy":  5,
+}
+
+func reviewKey(level, word string) string {
+	return strings.ToLower(level) + ":" + word
+}
+
+// 카드 키에서 단어 부분 (표시용)
+func reviewWord(key string) string {
+	_, word, found := strings.Cut(key, ":")
+	if !found {
+		return key
+	}
+	return word
+}
+
+// 예전 진행도 파일 정리: 단어만으로 된 카드 키를 "레벨:단어"로
+func normalizeReviews(progress *UserProgress) {
+	for key, card := range progress.Reviews {
+		if level, _, found := strings.Cut(key, ":"); found && level == strings.ToLower(card.Level) {
+			continue
+		}
+
+		delete(progress.Reviews, key)
+		newKey := reviewKey(card.Level, key)
+		if _, exists := progress.Reviews[newKey]; !exists {
+			progress.Reviews[newKey] = card
+		}
+		if progress.CurrentReview == key {
+			progress.CurrentReview = newKey
+		}
+	}
+}
+
+// 새로 배운 단어를 내일 복습하도록 등록 (이미 있으면 그대로 둠)
+func addReviewCard(progress *UserProgress, word, level string, now time.Time) {
+	if progress.Reviews == nil {
+		progress.Reviews = make(map[string]ReviewCard)
+	}
+	key := reviewKey(level, word)
+	if _, exists := progress.Reviews[key]; exists {
+		return
+	}
+
+	today := userNow(*progress, now)
+	progress.Reviews[key] = ReviewCard{
+		Level: level,
+		Due:   today.AddDate(0, 0, 1).Format("2006-01-02"),
+		Ease:  defaultEase,
+		Added: today.Format("2006-01-02"),
+	}
+}
+
+// 복습 카드가 없는 예전 학습 단어는 오늘 바로 복습하도록 등록
+func syncReviewCards(progress *UserProgress, now time.Time) {
+	if progress.Reviews == nil {
+		progress.Reviews = make(map[string]ReviewCard)
+	}
+
+	today := userNow(*progress, now).Format("2006-01-02")
+	for level, words := range progress.LearnedWords {
+		for _, word := range words {
+			key := reviewKey(level, word)
+			if _, exists := progress.Reviews[key]; !exists {
+				progress.Reviews[key] = ReviewCard{Level: level, Due: today, Ease: defaultEase}
+			}
+		}
+	}
+}
+
+// SM-2로 다음 복습일 계산 (now는 사용자 시간대 기준)
+func scheduleReview(card ReviewCard, quality int, now time.Time) ReviewCard {
+	if card.Ease == 0 {
+		card.Ease = defaultEase
+	}
+
+	if quality < 3 {
+		// 기억 실패: 처음부터 다시
+		card.Reps = 0
+		card.Lapses++
+		card.Interval = 1
+	} else {
+		card.Reps++
+		switch card.Reps {
+		case 1:
+			card.Interval = 1
+		case 2:
+			card.Interval = 6
+		default:
+			card.Interval = int(math.Round(float64(card.Interval) * card.Ease))
+		}
+	}
+
+	q := float64(5 - quality)
+	card.Ease += 0.1 - q*(0.08+q*0.02)
+	if card.Ease < minEase {
+		card.Ease = minEase
+	}
+
+	card.LastReview = now.Format("2006-01-02")
+	card.Due = now.AddDate(0, 0, card.Interval).Format("2006-01-02")
+	return card
+}
+
+// 오늘까지 복습할 단어 (오래 밀린 순)
+func dueReviews(progress UserProgress, now time.Time) []string {
+	today := userNow(progress, now).Format("2006-01-02")
+
+	var due []string
+	for word, card := range progress.Reviews {
+		if card.Due <= today {
+			due = append(due, word)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		a, b := progress.Reviews[due[i]], progress.Reviews[due[j]]
+		if a.Due != b.Due {
+			return a.Due < b.Due
+		}
+		return due[i] < due[j]
+	})
+	return due
+}
+
+// 가장 가까운 다음 복습일
+func nextReviewDate(progress UserProgress) string {
+	next := ""
+	for _, card := range progress.Reviews {
+		if next == "" || card.Due < next {
+			next = card.Due
+		}
+	}
+	return next
+}
+
+// ---------------- /review ----------------
+func handleReviewCommand(bot Messenger, chatID string) {
+	progress := loadUserProgress(chatID)
+	syncReviewCards(&progress, time.Now())
+	msg := nextReview(&progress)
+
+	// 차단으로 전송에 실패하면 비활성 표시가 저장되므로 먼저 저장
+	saveUserProgress(progress)
+	sendToTelegram(bot, chatID, msg)
+}
+
+// 다음 복습 카드 앞면 메시지 (없으면 다음 복습일 안내), CurrentReview도 그 카드로
+func nextReview(progress *UserProgress) string {
+	locale := userLocale(*progress)
+	due := dueReviews(*progress, time.Now())
+	if len(due) == 0 {
+		progress.CurrentReview = ""
+
+		msg := tr(locale, "review.done") + "\n\n"
+		if next := nextReviewDate(*progress); next != "" {
+			msg += tr(locale, "review.next_date", "date", next)
+		} else {
+			msg += tr(locale, "review.empty")
+		}
+		return msg
+	}
+
+	key := due[0]
+	card := progress.Reviews[key]
+	progress.CurrentReview = key
+
+	msg := tr(locale, "review.card", "level", levelName(card.Level), "count", len(due)) + "\n\n"
+	msg += fmt.Sprintf("*%s*\n\n", reviewWord(key))
+	msg += tr(locale, "review.prompt")
+	return msg
+}
+
+func handleShowCommand(bot Messenger, chatID string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	if progress.CurrentReview == "" {
+		sendToTelegram(bot, chatID, tr(locale, "review.start"))
+		return
+	}
+
+	word := reviewWord(progress.CurrentReview)
+	card := progress.Reviews[progress.CurrentReview]
+
+	msg := fmt.Sprintf("*%s*\n", word)
+	if w, ok := vocab.Find(card.Level, word); ok {
+		msg += fmt.Sprintf("📖 %s\n\n", wordGloss(w, userLanguage(progress)))
+		for _, ex := range w.Examples {
+			msg += fmt.Sprintf("💬 %s\n", ex)
+		}
+		msg += "\n"
+	}
+	msg += tr(locale, "review.grade_prompt")
+
+	sendToTelegram(bot, chatID, msg)
+}
+
+func handleGradeCommand(bot Messenger, chatID string, quality int) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	if progress.CurrentReview == "" {
+		sendToTelegram(bot, chatID, tr(locale, "review.start"))
+		return
+	}
+
+	now := time.Now()
+	key := progress.CurrentReview
+	word := reviewWord(key)
+	card := scheduleReview(progress.Reviews[key], quality, userNow(progress, now))
+	progress.Reviews[key] = card
+	recordStudy(&progress, now)
+
+	// 날짜별 복습 결과 (리포트 정답률)
+	if progress.ReviewLog == nil {
+		progress.ReviewLog = make(map[string]Score)
+	}
+	day := userNow(progress, now).Format("2006-01-02")
+	score := progress.ReviewLog[day]
+	if quality >= 3 {
+		score.Correct++
+	} else {
+		score.Wrong++
+	}
+	progress.ReviewLog[day] = score
+
+	fmt.Printf("✓ User %s reviewed %s (q=%d, next %s)\n", chatID, word, quality, card.Due)
+
+	next := nextReview(&progress)
+	saveUserProgress(progress)
+
+	sendToTelegram(bot, chatID, tr(locale, "review.graded", "word", word, "count", card.Interval, "date", card.Due))
+	sendToTelegram(bot, chatID, next)
+}
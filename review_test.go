@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// 같은 단어라도 레벨마다 복습 카드가 따로 생기는지
+func TestReviewCardPerLevel(t *testing.T) {
+	progress := newUserProgress("42")
+	now := time.Now()
+	markLearned(&progress, "das Haus", "a1", now)
+	markLearned(&progress, "das Haus", "b1", now)
+
+	for _, key := range []string{"a1:das Haus", "b1:das Haus"} {
+		if _, ok := progress.Reviews[key]; !ok {
+			t.Errorf("no review card %q: %v", key, progress.Reviews)
+		}
+	}
+}
+
+// 복습 날짜는 모두 사용자 시간대 기준
+func TestReviewCardUsesUserClock(t *testing.T) {
+	progress := newUserProgress("42")
+	progress.Timezone = "Asia/Seoul"
+
+	// 10월 18일 22:00 UTC = 10월 19일 07:00 KST
+	now := time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC)
+	markLearned(&progress, "das Haus", "a1", now)
+
+	card := progress.Reviews["a1:das Haus"]
+	if card.Added != "2026-10-19" || card.Due != "2026-10-20" {
+		t.Fatalf("added %s, due %s; want 2026-10-19, 2026-10-20", card.Added, card.Due)
+	}
+
+	if due := dueReviews(progress, now.AddDate(0, 0, 1)); len(due) != 1 {
+		t.Errorf("due on 2026-10-20 KST: %v", due)
+	}
+	card = scheduleReview(card, 4, userNow(progress, now.AddDate(0, 0, 1)))
+	if card.LastReview != "2026-10-20" {
+		t.Errorf("last_review = %s, want 2026-10-20", card.LastReview)
+	}
+}
+
+// 단어만으로 저장된 예전 카드는 불러올 때 "레벨:단어"로 바뀜
+func TestNormalizeReviews(t *testing.T) {
+	progress := newUserProgress("42")
+	progress.Reviews = map[string]ReviewCard{
+		"das Haus":    {Level: "A1", Due: "2026-10-01", Reps: 3},
+		"b1:die Frau": {Level: "b1", Due: "2026-10-02"},
+	}
+	progress.CurrentReview = "das Haus"
+
+	normalizeReviews(&progress)
+
+	if card, ok := progress.Reviews["a1:das Haus"]; !ok || card.Reps != 3 {
+		t.Errorf("old card not moved: %v", progress.Reviews)
+	}
+	if _, ok := progress.Reviews["b1:die Frau"]; !ok || len(progress.Reviews) != 2 {
+		t.Errorf("reviews after normalize: %v", progress.Reviews)
+	}
+	if progress.CurrentReview != "a1:das Haus" {
+		t.Errorf("current_review = %q, want a1:das Haus", progress.CurrentReview)
+	}
+}
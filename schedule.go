@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // 러너에 tzdata가 없어도 시간대 사용
+)
+
+// ---------------- 예약 수업 (/schedule) ----------------
+// 사용자가 고른 시각/시간대/요일에 자동으로 보내는 수업
+type LessonSchedule struct {
+	Time     string   `json:"time"`     // 15:04 (사용자 시간대 기준)
+	Timezone string   `json:"timezone"` // IANA 이름 (Asia/Seoul)
+	Level    string   `json:"level"`
+	Days     []string `json:"days"` // mon, tue, ... (비어 있으면 매일)
+}
+
+const defaultTimezone = "Asia/Seoul"
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// 요일 표시 이름 (월, Mon, Mo)
+func weekdayLabel(day, locale string) string {
+	return tr(locale, "weekday."+day)
+}
+
+// "daily", "weekdays", "weekends", "mon,wed,fri", "mon-fri" → 요일 목록
+func parseWeekdays(s string) ([]string, bool) {
+	switch strings.ToLower(s) {
+	case "daily":
+		return nil, true
+	case "weekdays":
+		return []string{"mon", "tue", "wed", "thu", "fri"}, true
+	case "weekends":
+		return []string{"sat", "sun"}, true
+	}
+
+	var days []string
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		start, ok := weekdayIndex(from)
+		if !ok {
+			return nil, false
+		}
+		end := start
+		if isRange {
+			if end, ok = weekdayIndex(to); !ok {
+				return nil, false
+			}
+		}
+		for i := start; ; i = (i + 1) % 7 {
+			days = append(days, weekdayNames[i])
+			if i == end {
+				break
+			}
+		}
+	}
+	return days, len(days) > 0
+}
+
+func weekdayIndex(name string) (int, bool) {
+	for i, n := range weekdayNames {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// "/schedule 07:30 Asia/Seoul a1 mon-fri" (시각 뒤의 값은 순서 상관없이 생략 가능)
+func parseSchedule(args []string, current *LessonSchedule) (LessonSchedule, error) {
+	schedule := LessonSchedule{Timezone: defaultTimezone, Level: "a1"}
+	if current != nil {
+		schedule = *current
+	}
+
+	clock, err := time.Parse("15:04", args[0])
+	if err != nil {
+		return schedule, newUserError("schedule.bad_time")
+	}
+	schedule.Time = clock.Format("15:04")
+
+	for _, arg := range args[1:] {
+		if _, ok := levelFilename(strings.ToLower(arg)); ok {
+			schedule.Level = strings.ToLower(arg)
+		} else if days, ok := parseWeekdays(arg); ok {
+			schedule.Days = days
+		} else if _, err := time.LoadLocation(arg); err == nil && arg != "" && arg != "Local" {
+			schedule.Timezone = arg
+		} else {
+			return schedule, newUserError("schedule.bad_value", "value", arg)
+		}
+	}
+	return schedule, nil
+}
+
+func formatSchedule(s LessonSchedule, locale string) string {
+	days := tr(locale, "schedule.daily")
+	if len(s.Days) > 0 {
+		labels := make([]string, len(s.Days))
+		for i, d := range s.Days {
+			labels[i] = weekdayLabel(d, locale)
+		}
+		days = strings.Join(labels, ", ")
+	}
+	return fmt.Sprintf("⏰ *%s* (%s) · %s · %s", s.Time, s.Timezone, levelName(s.Level), days)
+}
+
+func handleScheduleCommand(bot Messenger, chatID, text string) {
+	args := strings.Fields(text)[1:]
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+
+	if len(args) == 0 {
+		msg := tr(locale, "schedule.usage") + "\n\n"
+		if progress.Schedule != nil {
+			msg += tr(locale, "schedule.current", "schedule", formatSchedule(*progress.Schedule, locale))
+		} else {
+			msg += tr(locale, "schedule.none")
+		}
+		sendToTelegram(bot, chatID, msg)
+		return
+	}
+
+	if strings.EqualFold(args[0], "off") {
+		// 시간대는 날짜 경계에도 쓰므로 남겨둠
+		progress.Timezone = userTimezone(progress)
+		progress.Schedule = nil
+		saveUserProgress(progress)
+		sendToTelegram(bot, chatID, tr(locale, "schedule.off"))
+		return
+	}
+
+	// 예약을 껐다가 다시 켜면 예전 시간대 그대로
+	current := progress.Schedule
+	if current == nil && progress.Timezone != "" {
+		current = &LessonSchedule{Timezone: progress.Timezone, Level: "a1"}
+	}
+
+	schedule, err := parseSchedule(args, current)
+	if err != nil {
+		sendToTelegram(bot, chatID, tr(locale, "schedule.error", "error", errorText(locale, err)))
+		return
+	}
+
+	progress.Schedule = &schedule
+	progress.Timezone = schedule.Timezone
+	saveUserProgress(progress)
+	fmt.Printf("✓ User %s scheduled lessons at %s %s\n", chatID, schedule.Time, schedule.Timezone)
+
+	sendToTelegram(bot, chatID, tr(locale, "schedule.saved", "schedule", formatSchedule(schedule, locale)))
+}
+
+// 사용자 시간대 (예전 진행도는 예약 수업에만 시간대가 있음), 없으면 ""
+func userTimezone(progress UserProgress) string {
+	if progress.Timezone != "" {
+		return progress.Timezone
+	}
+	if progress.Schedule != nil {
+		return progress.Schedule.Timezone
+	}
+	return ""
+}
+
+// 사용자 시간대의 현재 시각 (시간대가 없거나 잘못되면 러너 시각)
+func userNow(progress UserProgress, now time.Time) time.Time {
+	tz := userTimezone(progress)
+	if tz == "" {
+		return now
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return now
+	}
+	return now.In(loc)
+}
+
+// 오늘 예약 수업을 보낼 때인지 (사용자 시간대 기준, 예약 시각이 지난 뒤 첫 실행)
+func scheduleDue(s LessonSchedule, local time.Time) bool {
+	if len(s.Days) > 0 {
+		today := weekdayNames[local.Weekday()]
+		found := false
+		for _, d := range s.Days {
+			if d == today {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	clock, err := time.Parse("15:04", s.Time)
+	if err != nil {
+		return false
+	}
+	at := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, local.Location())
+	return !local.Before(at)
+}
+
+// ---------------- 예약 수업 전송 ----------------
+func sendScheduledLessons(bot Messenger, now time.Time) {
+	for _, chatID := range loadChatIDs() {
+		progress := loadUserProgress(chatID)
+		if !wantsScheduledMessages(progress) || progress.Schedule == nil {
+			continue
+		}
+
+		local := userNow(progress, now)
+		today := local.Format("2006-01-02")
+
+		// 오늘 이미 보냈는지 확인 (여러 번 실행돼도 한 번만)
+		if progress.LastScheduledLesson == today || !scheduleDue(*progress.Schedule, local) {
+			continue
+		}
+
+		// 수업 전송 중에 진행도가 저장되므로 먼저 기록
+		progress.LastScheduledLesson = today
+		saveUserProgress(progress)
+
+		sendToTelegram(bot, chatID, tr(userLocale(progress), "schedule.lesson_time"))
+		sendLesson(bot, chatID, progress.Schedule.Level, "")
+
+		time.Sleep(100 * time.Millisecond) // Rate limiting
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// 1회 실행 cron(07–19시 UTC)만 도는 환경에서 Asia/Seoul 사용자도 월요일 안내를 받는지
+func TestMondayGuideWithinCronWindow(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/schedule 07:30 Asia/Seoul")
+	poll(t, bot)
+	srv.Reset()
+
+	// 월요일 07:00 KST (8am 전)
+	sendMondayWelcomeIfNeeded(bot, time.Date(2026, 10, 18, 22, 0, 0, 0, time.UTC))
+	if sent := srv.SentTo("42"); len(sent) != 0 {
+		t.Fatalf("sent before 8am local: %q", texts(sent))
+	}
+
+	// 월요일 첫 cron 실행 07:00 UTC = 16:00 KST
+	sendMondayWelcomeIfNeeded(bot, time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC))
+	sent := srv.SentTo("42")
+	if len(sent) != 1 || firstLine(sent[0].Text) != firstLine(tr(defaultLocale, "weekly_guide")) {
+		t.Fatalf("first run after 8am local: got %q, want the weekly guide", texts(sent))
+	}
+
+	sendMondayWelcomeIfNeeded(bot, time.Date(2026, 10, 19, 7, 2, 0, 0, time.UTC))
+	if sent := srv.SentTo("42"); len(sent) != 1 {
+		t.Errorf("weekly guide sent %d times on the same day", len(sent))
+	}
+}
+
+// 07:30 KST 예약 수업은 그날 첫 cron 실행(16:00 KST)에 한 번만
+func TestScheduledLessonWithinCronWindow(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/schedule 07:30 Asia/Seoul")
+	poll(t, bot)
+	srv.Reset()
+
+	// 07:00 KST (예약 시각 전)
+	sendScheduledLessons(bot, time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC))
+	if sent := srv.SentTo("42"); len(sent) != 0 {
+		t.Fatalf("sent before 07:30 local: %q", texts(sent))
+	}
+
+	// 10월 20일 첫 cron 실행 07:00 UTC = 16:00 KST
+	sendScheduledLessons(bot, time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC))
+	sent := srv.SentTo("42")
+	if len(sent) == 0 || sent[0].Text != tr(defaultLocale, "schedule.lesson_time") {
+		t.Fatalf("first run after 07:30 local: got %q, want the scheduled lesson", texts(sent))
+	}
+	if got := loadUserProgress("42").LastScheduledLesson; got != "2026-10-20" {
+		t.Errorf("last_scheduled_lesson = %q, want 2026-10-20", got)
+	}
+
+	srv.Reset()
+	sendScheduledLessons(bot, time.Date(2026, 10, 20, 7, 2, 0, 0, time.UTC))
+	if sent := srv.SentTo("42"); len(sent) != 0 {
+		t.Errorf("scheduled lesson sent again on the same day: %q", texts(sent))
+	}
+}
+
+func TestMonthlyReportWithinCronWindow(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/schedule 07:30 Asia/Seoul")
+	poll(t, bot)
+	srv.Reset()
+
+	progress := loadUserProgress("42")
+	progress.Activity = map[string]int{"2026-10-05": 3}
+	saveUserProgress(progress)
+
+	// 11월 1일 첫 cron 실행 07:00 UTC = 16:00 KST
+	sendMonthlyReportsIfNeeded(bot, time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC))
+	if sent := srv.SentTo("42"); len(sent) != 1 {
+		t.Fatalf("monthly report: got %d messages, want 1", len(sent))
+	}
+	if got := loadUserProgress("42").LastMonthlyReport; got != "2026-11" {
+		t.Errorf("last_monthly_report = %q, want 2026-11", got)
+	}
+}
+
+func TestScheduleOffKeepsTimezone(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	srv.AddMessage(42, "/start")
+	srv.AddMessage(42, "/schedule 07:30 Europe/Berlin")
+	srv.AddMessage(42, "/schedule off")
+	poll(t, bot)
+
+	progress := loadUserProgress("42")
+	if progress.Schedule != nil || progress.Timezone != "Europe/Berlin" {
+		t.Fatalf("after /schedule off: schedule = %v, timezone = %q", progress.Schedule, progress.Timezone)
+	}
+	if loc := userNow(progress, time.Now()).Location().String(); loc != "Europe/Berlin" {
+		t.Errorf("userNow location = %s, want Europe/Berlin", loc)
+	}
+
+	// 다시 켜면 기본값(Asia/Seoul) 대신 저장된 시간대
+	srv.AddMessage(42, "/schedule 09:00")
+	poll(t, bot)
+	if s := loadUserProgress("42").Schedule; s == nil || s.Timezone != "Europe/Berlin" {
+		t.Errorf("schedule after turning back on = %+v, want Europe/Berlin", s)
+	}
+}
+
+// timezone 필드 이전에 저장된 진행도는 예약 수업의 시간대를 사용
+func TestUserNowFallsBackToScheduleTimezone(t *testing.T) {
+	progress := UserProgress{Schedule: &LessonSchedule{Time: "07:30", Timezone: "Asia/Seoul"}}
+	if loc := userNow(progress, time.Now()).Location().String(); loc != "Asia/Seoul" {
+		t.Errorf("userNow location = %s, want Asia/Seoul", loc)
+	}
+}
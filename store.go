@@ -0,0 +1,440 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 진행도/등록 사용자/봇 상태 저장소
+type Store interface {
+	ChatIDs() ([]string, error)
+	AddChatIDs(ids []string) error
+	// 저장된 진행도가 없으면 found == false
+	LoadUser(chatID string) (progress UserProgress, found bool, err error)
+	SaveUser(progress UserProgress) error
+	// 등록된 chat ID와 진행도(학습 기록 포함)를 모두 삭제
+	DeleteUser(chatID string) error
+	LoadBotState() (state BotState, found bool, err error)
+	SaveBotState(state BotState) error
+	Close() error
+}
+
+// main에서 openStore()로 초기화
+var store Store
+
+// STORE=json(기본) | sqlite, SQLITE_PATH=bot.db
+func openStore() (Store, error) {
+	switch backend := os.Getenv("STORE"); backend {
+	case "", "json":
+		return newJSONStore("."), nil
+	case "sqlite":
+		return openSQLiteStore(sqlitePath())
+	default:
+		return nil, fmt.Errorf("unknown STORE %q (json, sqlite)", backend)
+	}
+}
+
+func sqlitePath() string {
+	if path := os.Getenv("SQLITE_PATH"); path != "" {
+		return path
+	}
+	return "bot.db"
+}
+
+// ---------------- 유저 진행도 관리 ----------------
+func newUserProgress(chatID string) UserProgress {
+	return UserProgress{
+		ChatID:       chatID,
+		LearnedWords: newLevelProgress(),
+		LastStudy:    "처음",
+	}
+}
+
+func loadUserProgress(chatID string) UserProgress {
+	progress, found, err := store.LoadUser(chatID)
+	if err != nil {
+		fmt.Printf("❌ Error loading progress for %s: %v\n", chatID, err)
+	}
+	if err != nil || !found {
+		// 진행도가 없으면 새로 생성
+		return newUserProgress(chatID)
+	}
+	progress.LearnedWords = normalizeLevelProgress(progress.LearnedWords)
+	normalizeReviews(&progress)
+	return progress
+}
+
+func saveUserProgress(progress UserProgress) {
+	if err := store.SaveUser(progress); err != nil {
+		fmt.Printf("❌ Error saving progress for %s: %v\n", progress.ChatID, err)
+	} else {
+		var counts []string
+		for _, level := range progressLevels(progress.LearnedWords) {
+			counts = append(counts, fmt.Sprintf("%s:%d", levelName(level), len(progress.LearnedWords[level])))
+		}
+		fmt.Printf("✓ Saved progress for %s (%s)\n", progress.ChatID, strings.Join(counts, ", "))
+	}
+}
+
+// ---------------- 봇 상태 관리 ----------------
+func loadBotState() BotState {
+	state, _, err := store.LoadBotState()
+	if err != nil {
+		fmt.Println("❌ Error loading bot state:", err)
+	}
+	return state
+}
+
+func saveBotState(state BotState) {
+	if err := store.SaveBotState(state); err != nil {
+		fmt.Println("❌ Error saving bot state:", err)
+	}
+}
+
+// ---------------- 등록 사용자 관리 ----------------
+func loadChatIDs() []string {
+	ids, err := store.ChatIDs()
+	if err != nil {
+		fmt.Println("❌ Error loading chat IDs:", err)
+		return []string{}
+	}
+	return ids
+}
+
+func mergeChatIDs(newIDs []string) {
+	if err := store.AddChatIDs(newIDs); err != nil {
+		fmt.Println("❌ Error saving chat IDs:", err)
+	}
+}
+
+// ---------------- JSON 파일 저장소 ----------------
+// 기존 레이아웃: chat_ids.json, bot_state.json, user_progress/<chatID>_progress.json
+type jsonStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func newJSONStore(dir string) *jsonStore {
+	return &jsonStore{dir: dir}
+}
+
+func (s *jsonStore) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *jsonStore) progressPath(chatID string) string {
+	return filepath.Join(s.dir, userProgressDir, chatID+"_progress.json")
+}
+
+func (s *jsonStore) ChatIDs() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readChatIDs()
+}
+
+func (s *jsonStore) readChatIDs() ([]string, error) {
+	data, err := os.ReadFile(s.path(chatIDFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", chatIDFile, err)
+	}
+	return dedupeChatIDs(ids), nil
+}
+
+// 중복된 chat ID 제거 (처음 나온 순서 유지)
+func dedupeChatIDs(ids []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			unique = append(unique, id)
+			seen[id] = true
+		}
+	}
+	return unique
+}
+
+func (s *jsonStore) AddChatIDs(newIDs []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, err := s.readChatIDs()
+	if err != nil {
+		return err
+	}
+
+	data, _ := json.Marshal(dedupeChatIDs(append(ids, newIDs...)))
+	return writeFileAtomic(s.path(chatIDFile), data)
+}
+
+func (s *jsonStore) LoadUser(chatID string) (UserProgress, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.progressPath(chatID))
+	if errors.Is(err, os.ErrNotExist) {
+		return UserProgress{}, false, nil
+	}
+	if err != nil {
+		return UserProgress{}, false, err
+	}
+
+	var progress UserProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return UserProgress{}, false, err
+	}
+	return progress, true, nil
+}
+
+func (s *jsonStore) SaveUser(progress UserProgress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(s.dir, userProgressDir), 0755); err != nil {
+		return err
+	}
+
+	data, _ := json.MarshalIndent(progress, "", "  ")
+	return writeFileAtomic(s.progressPath(progress.ChatID), data)
+}
+
+func (s *jsonStore) DeleteUser(chatID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, err := s.readChatIDs()
+	if err != nil {
+		return err
+	}
+	kept := ids[:0]
+	for _, id := range ids {
+		if id != chatID {
+			kept = append(kept, id)
+		}
+	}
+	data, _ := json.Marshal(kept)
+	if err := writeFileAtomic(s.path(chatIDFile), data); err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.progressPath(chatID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// user_progress/ 안의 모든 진행도 파일 (chat_ids.json에 없는 것도 포함)
+func (s *jsonStore) progressChatIDs() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, userProgressDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if id, ok := strings.CutSuffix(entry.Name(), "_progress.json"); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s *jsonStore) LoadBotState() (BotState, bool, error) {
+	state, found, err := s.readBotState()
+	if err == nil && !found {
+		// 상태 파일이 없으면 예전 유저별 last_update_id에서 이관
+		state, err = s.migrateLegacyUpdateIDs()
+		return state, err == nil, err
+	}
+	return state, found, err
+}
+
+// bot_state.json만 읽음 (없으면 found=false, 이관하지 않음)
+func (s *jsonStore) readBotState() (BotState, bool, error) {
+	s.mu.Lock()
+	data, err := os.ReadFile(s.path(botStateFile))
+	s.mu.Unlock()
+
+	if errors.Is(err, os.ErrNotExist) {
+		return BotState{}, false, nil
+	}
+	if err != nil {
+		return BotState{}, false, err
+	}
+
+	var state BotState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return BotState{}, false, fmt.Errorf("parsing %s: %w", botStateFile, err)
+	}
+	return state, true, nil
+}
+
+func (s *jsonStore) SaveBotState(state BotState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, _ := json.MarshalIndent(state, "", "  ")
+	return writeFileAtomic(s.path(botStateFile), data)
+}
+
+// 유저별 last_update_id 중 최대값을 봇 전체 offset으로 사용하고,
+// 진행도 파일을 다시 저장해서 예전 필드를 제거
+func (s *jsonStore) migrateLegacyUpdateIDs() (BotState, error) {
+	state, err := s.legacyBotState()
+	if err != nil {
+		return state, err
+	}
+
+	// 다시 저장하면 진행도 파일에서 last_update_id가 빠짐
+	chatIDs, err := s.ChatIDs()
+	if err != nil {
+		return state, err
+	}
+	for _, chatID := range chatIDs {
+		if progress, found, err := s.LoadUser(chatID); err == nil && found {
+			s.SaveUser(progress)
+		}
+	}
+
+	if err := s.SaveBotState(state); err != nil {
+		return state, err
+	}
+	fmt.Printf("✓ Migrated update offset to %s: %d\n", botStateFile, state.LastUpdateID)
+	return state, nil
+}
+
+// 예전 유저별 last_update_id 중 가장 큰 값 (파일은 고치지 않음)
+func (s *jsonStore) legacyBotState() (BotState, error) {
+	var state BotState
+
+	chatIDs, err := s.ChatIDs()
+	if err != nil {
+		return state, err
+	}
+
+	for _, chatID := range chatIDs {
+		data, err := os.ReadFile(s.progressPath(chatID))
+		if err != nil {
+			continue
+		}
+
+		var legacy struct {
+			LastUpdateID int `json:"last_update_id"`
+		}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			continue
+		}
+		if legacy.LastUpdateID > state.LastUpdateID {
+			state.LastUpdateID = legacy.LastUpdateID
+		}
+	}
+	return state, nil
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
+
+// 임시 파일에 쓰고 rename해서 중간에 죽어도 파일이 깨지지 않게 함
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ---------------- JSON → SQLite 이관 ----------------
+// go run . import : 현재 디렉터리의 JSON 파일을 SQLITE_PATH로 복사
+func runImport() {
+	src := newJSONStore(".")
+	dst, err := openSQLiteStore(sqlitePath())
+	if err != nil {
+		fmt.Println("Error opening SQLite store:", err)
+		os.Exit(1)
+	}
+	defer dst.Close()
+
+	if err := importStore(src, dst); err != nil {
+		fmt.Println("Error importing:", err)
+		os.Exit(1)
+	}
+}
+
+func importStore(src *jsonStore, dst Store) error {
+	chatIDs, err := src.ChatIDs()
+	if err != nil {
+		return err
+	}
+	if err := dst.AddChatIDs(chatIDs); err != nil {
+		return err
+	}
+
+	progressIDs, err := src.progressChatIDs()
+	if err != nil {
+		return err
+	}
+
+	users := 0
+	for _, chatID := range progressIDs {
+		progress, found, err := src.LoadUser(chatID)
+		if err != nil {
+			fmt.Printf("⚠️ Skipping %s: %v\n", chatID, err)
+			continue
+		}
+		if !found {
+			continue
+		}
+		if progress.ChatID == "" {
+			progress.ChatID = chatID
+		}
+		if err := dst.SaveUser(progress); err != nil {
+			return fmt.Errorf("saving %s: %w", chatID, err)
+		}
+		users++
+	}
+
+	// 원본 JSON 파일은 건드리지 않도록 이관하지 않고 읽기만
+	state, found, err := src.readBotState()
+	if err == nil && !found {
+		state, err = src.legacyBotState()
+	}
+	if err != nil {
+		return err
+	}
+	if err := dst.SaveBotState(state); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Imported %d chat IDs, %d users, update offset %d\n", len(chatIDs), users, state.LastUpdateID)
+	return nil
+}
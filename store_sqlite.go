@@ -0,0 +1,198 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// ---------------- SQLite 저장소 ----------------
+// 진행도의 learned_words는 별도 테이블, 나머지 필드는 JSON으로 저장
+type sqliteStore struct {
+	db *sql.DB
+}
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS chats (
+	chat_id TEXT PRIMARY KEY
+);
+CREATE TABLE IF NOT EXISTS users (
+	chat_id  TEXT PRIMARY KEY,
+	progress TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS learned_words (
+	chat_id  TEXT NOT NULL,
+	level    TEXT NOT NULL,
+	word     TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	PRIMARY KEY (chat_id, level, word)
+);
+CREATE TABLE IF NOT EXISTS bot_state (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+
+func openSQLiteStore(path string) (*sqliteStore, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, err
+	}
+	// 쓰기는 한 연결로 직렬화
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (s *sqliteStore) ChatIDs() ([]string, error) {
+	rows, err := s.db.Query(`SELECT chat_id FROM chats ORDER BY rowid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (s *sqliteStore) AddChatIDs(ids []string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range ids {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO chats (chat_id) VALUES (?)`, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) LoadUser(chatID string) (UserProgress, bool, error) {
+	var raw string
+	err := s.db.QueryRow(`SELECT progress FROM users WHERE chat_id = ?`, chatID).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return UserProgress{}, false, nil
+	}
+	if err != nil {
+		return UserProgress{}, false, err
+	}
+
+	var progress UserProgress
+	if err := json.Unmarshal([]byte(raw), &progress); err != nil {
+		return UserProgress{}, false, err
+	}
+	progress.ChatID = chatID
+	progress.LearnedWords = newLevelProgress()
+
+	rows, err := s.db.Query(`SELECT level, word FROM learned_words WHERE chat_id = ? ORDER BY position`, chatID)
+	if err != nil {
+		return UserProgress{}, false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var level, word string
+		if err := rows.Scan(&level, &word); err != nil {
+			return UserProgress{}, false, err
+		}
+		progress.LearnedWords[level] = append(progress.LearnedWords[level], word)
+	}
+	return progress, true, rows.Err()
+}
+
+func (s *sqliteStore) SaveUser(progress UserProgress) error {
+	// learned_words를 뺀 나머지 필드만 JSON으로
+	var fields map[string]json.RawMessage
+	data, _ := json.Marshal(progress)
+	json.Unmarshal(data, &fields)
+	delete(fields, "learned_words")
+	data, _ = json.Marshal(fields)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO users (chat_id, progress) VALUES (?, ?)
+		ON CONFLICT (chat_id) DO UPDATE SET progress = excluded.progress`,
+		progress.ChatID, string(data)); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM learned_words WHERE chat_id = ?`, progress.ChatID); err != nil {
+		return err
+	}
+
+	position := 0
+	for _, level := range progressLevels(progress.LearnedWords) {
+		for _, word := range progress.LearnedWords[level] {
+			position++
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO learned_words (chat_id, level, word, position) VALUES (?, ?, ?, ?)`,
+				progress.ChatID, level, word, position); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) DeleteUser(chatID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"chats", "users", "learned_words"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE chat_id = ?`, chatID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) LoadBotState() (BotState, bool, error) {
+	var raw string
+	err := s.db.QueryRow(`SELECT value FROM bot_state WHERE key = 'state'`).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return BotState{}, false, nil
+	}
+	if err != nil {
+		return BotState{}, false, err
+	}
+
+	var state BotState
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return BotState{}, false, err
+	}
+	return state, true, nil
+}
+
+func (s *sqliteStore) SaveBotState(state BotState) error {
+	data, _ := json.Marshal(state)
+	_, err := s.db.Exec(`INSERT INTO bot_state (key, value) VALUES ('state', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, string(data))
+	return err
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// import는 예전 last_update_id를 읽기만 하고 원본 JSON 파일을 고치지 않음
+func TestImportLeavesJSONUntouched(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		chatIDFile:                      `["1", "2"]`,
+		"user_progress/1_progress.json": `{"chat_id": "1", "last_update_id": 120}`,
+		"user_progress/2_progress.json": `{"chat_id": "2", "last_update_id": 95}`,
+	}
+	if err := os.Mkdir(filepath.Join(dir, "user_progress"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst, err := openSQLiteStore(filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	if err := importStore(newJSONStore(dir), dst); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s changed by import:\n%s", name, got)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, botStateFile)); !os.IsNotExist(err) {
+		t.Errorf("import created %s", botStateFile)
+	}
+
+	state, _, err := dst.LoadBotState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.LastUpdateID != 120 {
+		t.Errorf("imported last_update_id = %d, want 120", state.LastUpdateID)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// ---------------- 연속 학습 (Streak) ----------------
+type StreakState struct {
+	Current    int      `json:"current"`
+	Longest    int      `json:"longest"`
+	LastDay    string   `json:"last_day,omitempty"` // 마지막으로 학습한 날 (2006-01-02)
+	Freezes    int      `json:"freezes"`            // 남은 프리즈 수
+	FrozenDays []string `json:"frozen_days,omitempty"`
+}
+
+// 연속 학습 며칠마다 프리즈 1개 지급, 최대 보유 수
+const freezeEveryDays = 7
+const maxFreezes = 2
+
+// 학습 기록: 마지막 학습일, 날짜별 활동 수, 연속 학습 갱신 (사용자 시간대 기준)
+func recordStudy(progress *UserProgress, now time.Time) {
+	now = userNow(*progress, now)
+	today := now.Format("2006-01-02")
+	previous := progress.LastStudy
+	progress.LastStudy = today
+
+	if progress.Activity == nil {
+		progress.Activity = make(map[string]int)
+	}
+	progress.Activity[today]++
+
+	s := &progress.Streak
+	// streak 기능 이전 사용자는 마지막 학습일을 1일째로 보고 이어서 계산
+	if _, err := time.Parse("2006-01-02", previous); err == nil && s.LastDay == "" {
+		s.LastDay = previous
+		s.Current = 1
+		s.Longest = max(s.Longest, 1)
+	}
+	if s.LastDay == today {
+		return
+	}
+
+	missed := missedDays(s.LastDay, now)
+	switch {
+	case s.LastDay == "":
+		s.Current = 1
+	case missed == 0:
+		s.Current++
+	case missed <= s.Freezes:
+		// 빠진 날은 프리즈로 메움
+		last, _ := time.Parse("2006-01-02", s.LastDay)
+		for i := 1; i <= missed; i++ {
+			s.FrozenDays = append(s.FrozenDays, last.AddDate(0, 0, i).Format("2006-01-02"))
+		}
+		s.Freezes -= missed
+		s.Current++
+	default:
+		s.Current = 1
+	}
+	s.LastDay = today
+
+	if s.Current > s.Longest {
+		s.Longest = s.Current
+	}
+	if s.Current%freezeEveryDays == 0 && s.Freezes < maxFreezes {
+		s.Freezes++
+	}
+}
+
+// lastDay 다음 날부터 now 전날까지 학습하지 않은 날 수
+func missedDays(lastDay string, now time.Time) int {
+	last, err := time.Parse("2006-01-02", lastDay)
+	if err != nil {
+		return 0
+	}
+	today, _ := time.Parse("2006-01-02", now.Format("2006-01-02"))
+	days := int(today.Sub(last).Hours()/24) - 1
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// 오늘 기준 연속 학습 일수 (프리즈로 메울 수 없을 만큼 쉬었으면 0)
+func currentStreak(s StreakState, now time.Time) int {
+	if s.LastDay == "" || missedDays(s.LastDay, now) > s.Freezes {
+		return 0
+	}
+	return s.Current
+}
+
+func formatStreakLine(s StreakState, now time.Time, locale string) string {
+	return tr(locale, "streak.line", "count", currentStreak(s, now), "longest", s.Longest, "freezes", s.Freezes)
+}
+
+// ---------------- /streak ----------------
+func handleStreakCommand(bot Messenger, chatID string) {
+	progress := loadUserProgress(chatID)
+	locale := userLocale(progress)
+	now := userNow(progress, time.Now())
+	s := progress.Streak
+	current := currentStreak(s, now)
+
+	frozen := make(map[string]bool)
+	for _, d := range s.FrozenDays {
+		frozen[d] = true
+	}
+
+	// 최근 7일 (오래된 날 → 오늘)
+	var days []string
+	for i := 6; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i).Format("2006-01-02")
+		switch {
+		case progress.Activity[day] > 0:
+			days = append(days, "✅")
+		case frozen[day]:
+			days = append(days, "❄️")
+		default:
+			days = append(days, "⬜")
+		}
+	}
+
+	msg := tr(locale, "streak.title") + "\n\n"
+	msg += tr(locale, "streak.current", "count", current) + "\n"
+	msg += tr(locale, "streak.longest", "count", s.Longest) + "\n"
+	msg += tr(locale, "streak.freezes", "count", s.Freezes, "max", maxFreezes) + "\n\n"
+	msg += tr(locale, "streak.week", "days", strings.Join(days, " ")) + "\n\n"
+
+	switch {
+	case progress.Activity[now.Format("2006-01-02")] > 0:
+		msg += tr(locale, "streak.done_today")
+	case current > 0 && missedDays(s.LastDay, now) > 0:
+		msg += tr(locale, "streak.frozen", "count", current+1)
+	case current > 0:
+		msg += tr(locale, "streak.not_yet")
+	default:
+		msg += tr(locale, "streak.start")
+	}
+	msg += "\n\n" + tr(locale, "streak.footer", "count", freezeEveryDays, "max", maxFreezes)
+
+	sendToTelegram(bot, chatID, msg)
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// 핸들러가 사용하는 텔레그램 기능 (테스트에서는 telegramtest 가짜 서버로 교체)
+type Messenger interface {
+	SendMessage(chatID, text string) error
+	GetUpdates(ctx context.Context, offset, timeout int) ([]Update, error)
+
+	// 인라인 키보드 (퀴즈 등)
+	SendKeyboard(chatID, text string, keyboard [][]InlineButton) (messageID int, err error)
+	EditMessage(chatID string, messageID int, text string, keyboard [][]InlineButton) error
+	AnswerCallbackQuery(callbackID, text string) error
+}
+
+type InlineButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// 봇이 받는 업데이트 종류
+const allowedUpdates = `["message","callback_query"]`
+
+// 일시적인 오류(429, 5xx, 네트워크)일 때 최대 시도 횟수, 첫 재시도 대기 시간 (매번 2배)
+const defaultMaxAttempts = 4
+const defaultBackoff = time.Second
+
+// 429 retry_after가 이보다 길면 기다리지 않고 오류 반환 (1회 실행이 오래 멈추지 않도록)
+const maxRetryAfter = 30 * time.Second
+
+// Bot API 클라이언트
+// BaseURL을 바꾸면 로컬 Bot API 서버나 telegramtest.Server로 요청을 보낼 수 있음
+type TelegramClient struct {
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+
+	// 0이면 기본값 (defaultMaxAttempts, defaultBackoff)
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// Bot API가 ok: false로 응답한 오류
+// {"ok": false, "error_code": 429, "description": "...", "parameters": {"retry_after": 5}}
+type APIError struct {
+	Method      string
+	StatusCode  int // HTTP 상태
+	Code        int // error_code (응답을 읽지 못했으면 0)
+	Description string
+	RetryAfter  time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s failed (HTTP %d): %s", e.Method, e.StatusCode, e.Description)
+}
+
+// 다시 보내면 성공할 수 있는 오류 (flood limit, 텔레그램 서버 오류)
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Markdown 서식이 깨져서 거절된 메시지 (단어에 _ 나 * 가 있는 경우 등)
+func isMarkdownError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest &&
+		strings.Contains(strings.ToLower(apiErr.Description), "can't parse entities")
+}
+
+// 사용자가 봇을 차단했거나 채팅이 없어져서 다시 보내도 소용없는 오류
+// 403 "Forbidden: bot was blocked by the user", 400 "Bad Request: chat not found"
+func isBlockedError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusForbidden ||
+		apiErr.StatusCode == http.StatusBadRequest && strings.Contains(strings.ToLower(apiErr.Description), "chat not found")
+}
+
+// TELEGRAM_API_URL이 설정되어 있으면 그 주소를 사용
+func NewTelegramClient(token string) *TelegramClient {
+	baseURL := os.Getenv("TELEGRAM_API_URL")
+	if baseURL == "" {
+		baseURL = defaultTelegramAPIURL
+	}
+
+	return &TelegramClient{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		HTTP:    &http.Client{},
+	}
+}
+
+func (c *TelegramClient) methodURL(method string) string {
+	return fmt.Sprintf("%s/bot%s/%s", c.BaseURL, c.Token, method)
+}
+
+// 일시적인 오류면 retry_after 또는 지수 백오프만큼 기다렸다가 다시 호출
+// (sendMessage는 응답을 못 받은 채 재시도하면 드물게 중복 전송될 수 있음)
+func (c *TelegramClient) call(ctx context.Context, method string, params url.Values, result interface{}) error {
+	for attempt := 1; ; attempt++ {
+		err := c.do(ctx, method, params, result)
+		wait, retry := c.retryDelay(ctx, err, attempt)
+		if !retry {
+			return err
+		}
+
+		fmt.Printf("⚠️ %v, retrying in %s\n", err, wait)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *TelegramClient) retryDelay(ctx context.Context, err error, attempt int) (time.Duration, bool) {
+	maxAttempts, backoff := c.MaxAttempts, c.Backoff
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+	if err == nil || attempt >= maxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if !apiErr.Temporary() {
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, apiErr.RetryAfter <= maxRetryAfter
+		}
+	}
+	// 네트워크 오류 또는 retry_after 없는 429/5xx
+	return backoff << (attempt - 1), true
+}
+
+// 공통 응답 형식 {"ok": ..., "result": ..., "error_code": ..., "description": ..., "parameters": ...}
+func (c *TelegramClient) do(ctx context.Context, method string, params url.Values, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.methodURL(method), strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Ok          bool            `json:"ok"`
+		Result      json.RawMessage `json:"result"`
+		ErrorCode   int             `json:"error_code"`
+		Description string          `json:"description"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		// 프록시 오류 페이지 등 (HTTP 상태로 재시도 여부 판단)
+		return &APIError{Method: method, StatusCode: resp.StatusCode, Description: "decoding response: " + err.Error()}
+	}
+	if !envelope.Ok {
+		return &APIError{
+			Method:      method,
+			StatusCode:  resp.StatusCode,
+			Code:        envelope.ErrorCode,
+			Description: envelope.Description,
+			RetryAfter:  time.Duration(envelope.Parameters.RetryAfter) * time.Second,
+		}
+	}
+	if result != nil {
+		return json.Unmarshal(envelope.Result, result)
+	}
+	return nil
+}
+
+func (c *TelegramClient) SendMessage(chatID, text string) error {
+	params := url.Values{}
+	params.Set("chat_id", chatID)
+	params.Set("text", text)
+	params.Set("parse_mode", "Markdown")
+
+	return c.callFormatted("sendMessage", params, nil)
+}
+
+func (c *TelegramClient) SendKeyboard(chatID, text string, keyboard [][]InlineButton) (int, error) {
+	params := url.Values{}
+	params.Set("chat_id", chatID)
+	params.Set("text", text)
+	params.Set("parse_mode", "Markdown")
+	params.Set("reply_markup", inlineKeyboardJSON(keyboard))
+
+	var sent Message
+	if err := c.callFormatted("sendMessage", params, &sent); err != nil {
+		return 0, err
+	}
+	return sent.MessageID, nil
+}
+
+// keyboard가 비어 있으면 버튼 제거
+func (c *TelegramClient) EditMessage(chatID string, messageID int, text string, keyboard [][]InlineButton) error {
+	params := url.Values{}
+	params.Set("chat_id", chatID)
+	params.Set("message_id", fmt.Sprintf("%d", messageID))
+	params.Set("text", text)
+	params.Set("parse_mode", "Markdown")
+	if len(keyboard) > 0 {
+		params.Set("reply_markup", inlineKeyboardJSON(keyboard))
+	}
+
+	return c.callFormatted("editMessageText", params, nil)
+}
+
+// Markdown 서식 때문에 거절되면 parse_mode 없이 한 번 더 (글자는 그대로 보임)
+func (c *TelegramClient) callFormatted(method string, params url.Values, result interface{}) error {
+	err := c.call(context.Background(), method, params, result)
+	if !isMarkdownError(err) {
+		return err
+	}
+
+	fmt.Printf("⚠️ %v, resending without Markdown\n", err)
+	params.Del("parse_mode")
+	return c.call(context.Background(), method, params, result)
+}
+
+func (c *TelegramClient) AnswerCallbackQuery(callbackID, text string) error {
+	params := url.Values{}
+	params.Set("callback_query_id", callbackID)
+	if text != "" {
+		params.Set("text", text)
+	}
+
+	return c.call(context.Background(), "answerCallbackQuery", params, nil)
+}
+
+func inlineKeyboardJSON(keyboard [][]InlineButton) string {
+	data, _ := json.Marshal(map[string][][]InlineButton{"inline_keyboard": keyboard})
+	return string(data)
+}
+
+// timeout > 0이면 롱폴링 (serve 모드)
+func (c *TelegramClient) GetUpdates(ctx context.Context, offset, timeout int) ([]Update, error) {
+	params := url.Values{}
+	params.Set("allowed_updates", allowedUpdates)
+	if offset > 0 {
+		params.Set("offset", fmt.Sprintf("%d", offset))
+	}
+	if timeout > 0 {
+		params.Set("timeout", fmt.Sprintf("%d", timeout))
+	}
+
+	// 롱폴링 대기 시간보다 조금 길게
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout+10)*time.Second)
+	defer cancel()
+
+	var updates []Update
+	if err := c.call(ctx, "getUpdates", params, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+func (c *TelegramClient) SetWebhook(webhookURL, secret string) error {
+	params := url.Values{}
+	params.Set("url", webhookURL)
+	params.Set("secret_token", secret)
+	params.Set("allowed_updates", allowedUpdates)
+
+	return c.call(context.Background(), "setWebhook", params, nil)
+}
+
+// ---------------- 텔레그램 전송 ----------------
+// 실패하면 로그를 남기고 오류 반환 (*APIError면 error_code/description 포함)
+// 봇이 차단된 경우 사용자를 비활성으로 표시
+func sendToTelegram(bot Messenger, chatID, message string) error {
+	if err := bot.SendMessage(chatID, message); err != nil {
+		fmt.Printf("❌ Error sending message to %s: %v\n", chatID, err)
+		if isBlockedError(err) {
+			deactivateUser(chatID)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ Sent message to %s\n", chatID)
+	return nil
+}
+
+// 버튼 메시지 전송 (차단한 사용자는 sendToTelegram처럼 비활성으로 표시, 로그는 호출하는 쪽에서)
+func sendKeyboard(bot Messenger, chatID, text string, keyboard [][]InlineButton) (int, error) {
+	messageID, err := bot.SendKeyboard(chatID, text, keyboard)
+	if isBlockedError(err) {
+		deactivateUser(chatID)
+	}
+	return messageID, err
+}
+
+func editMessage(bot Messenger, chatID string, messageID int, text string, keyboard [][]InlineButton) error {
+	err := bot.EditMessage(chatID, messageID, text, keyboard)
+	if isBlockedError(err) {
+		deactivateUser(chatID)
+	}
+	return err
+}
+
+// sendLongMessage splits long messages and sends them in parts
+// It stops at the first part that fails and returns that error.
+func sendLongMessage(bot Messenger, chatID, message string) error {
+	if len(message) <= maxMessageLength {
+		return sendToTelegram(bot, chatID, message)
+	}
+
+	// Split by "---" separator (word boundaries)
+	// 구분자 없이 긴 부분(/admin users 목록 등)은 줄 단위로 다시 나눔
+	var pieces []string
+	parts := strings.Split(message, "---\n\n")
+	for i, part := range parts {
+		// Add back the separator except for the last part
+		if i < len(parts)-1 {
+			part += "---\n\n"
+		}
+		pieces = append(pieces, splitLines(part, maxMessageLength)...)
+	}
+
+	currentMsg := ""
+	for _, piece := range pieces {
+		if len(currentMsg)+len(piece) > maxMessageLength && currentMsg != "" {
+			// Send current message and start new one
+			if err := sendToTelegram(bot, chatID, currentMsg); err != nil {
+				return err
+			}
+			time.Sleep(200 * time.Millisecond) // Rate limiting
+			currentMsg = ""
+		}
+		currentMsg += piece
+	}
+
+	// Send remaining message
+	if currentMsg != "" {
+		return sendToTelegram(bot, chatID, currentMsg)
+	}
+	return nil
+}
+
+// Telegram limit is 4096, use 4000 for safety
+const maxMessageLength = 4000
+
+// maxLength보다 긴 글을 줄 단위로 나눔 (한 줄이 너무 길면 글자 단위로 자름)
+func splitLines(text string, maxLength int) []string {
+	if len(text) <= maxLength {
+		return []string{text}
+	}
+
+	var chunks []string
+	current := ""
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if len(current)+len(line) > maxLength && current != "" {
+			chunks = append(chunks, current)
+			current = ""
+		}
+		for len(line) > maxLength {
+			cut := maxLength
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			chunks = append(chunks, line[:cut])
+			line = line[cut:]
+		}
+		current += line
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
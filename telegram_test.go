@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sinramyeon/german-daily-bot/telegramtest"
+)
+
+// 메서드별 요청 횟수를 세는 Transport
+type countingTransport struct {
+	next  http.RoundTripper
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	t.mu.Lock()
+	t.calls[method]++
+	t.mu.Unlock()
+	return t.next.RoundTrip(r)
+}
+
+func (t *countingTransport) count(method string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls[method]
+}
+
+// 재시도 대기를 짧게 한 클라이언트
+func newRetryClient(t *testing.T) (*telegramtest.Server, *TelegramClient, *countingTransport) {
+	t.Helper()
+
+	srv := telegramtest.NewServer("TOKEN")
+	t.Cleanup(srv.Close)
+
+	transport := &countingTransport{next: srv.Client().Transport, calls: make(map[string]int)}
+	bot := &TelegramClient{
+		BaseURL:     srv.URL,
+		Token:       "TOKEN",
+		HTTP:        &http.Client{Transport: transport},
+		MaxAttempts: 4,
+		Backoff:     time.Millisecond,
+	}
+	return srv, bot, transport
+}
+
+func TestSendRetriesTemporaryErrors(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 502, Description: "Bad Gateway"})
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 429, Description: "Too Many Requests: retry after 0"})
+
+	if err := bot.SendMessage("42", "Hallo"); err != nil {
+		t.Fatal(err)
+	}
+	if n := transport.count("sendMessage"); n != 3 {
+		t.Errorf("sendMessage attempts = %d, want 3", n)
+	}
+	if sent := srv.SentTo("42"); len(sent) != 1 {
+		t.Errorf("sent %d messages, want 1", len(sent))
+	}
+}
+
+func TestSendGivesUpAfterMaxAttempts(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	for i := 0; i < 5; i++ {
+		srv.FailNext("sendMessage", telegramtest.Failure{Code: 500, Description: "Internal Server Error"})
+	}
+
+	err := bot.SendMessage("42", "Hallo")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.Method != "sendMessage" || apiErr.StatusCode != 500 || apiErr.Code != 500 || apiErr.Description != "Internal Server Error" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if n := transport.count("sendMessage"); n != 4 {
+		t.Errorf("sendMessage attempts = %d, want 4", n)
+	}
+}
+
+func TestSendDoesNotRetryPermanentErrors(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 400, Description: "Bad Request: message text is empty"})
+
+	if err := bot.SendMessage("42", "Hallo"); err == nil {
+		t.Fatal("want an error for 400")
+	}
+	if n := transport.count("sendMessage"); n != 1 {
+		t.Errorf("sendMessage attempts = %d, want 1", n)
+	}
+}
+
+func TestSendHonorsRetryAfter(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 429, Description: "Too Many Requests: retry after 1", RetryAfter: 1})
+
+	start := time.Now()
+	if err := bot.SendMessage("42", "Hallo"); err != nil {
+		t.Fatal(err)
+	}
+	// Backoff(1ms) 대신 retry_after만큼 기다림
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least retry_after 1s", elapsed)
+	}
+	if n := transport.count("sendMessage"); n != 2 {
+		t.Errorf("sendMessage attempts = %d, want 2", n)
+	}
+}
+
+func TestSendGivesUpOnLongRetryAfter(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 429, Description: "Too Many Requests: retry after 60", RetryAfter: 60})
+
+	err := bot.SendMessage("42", "Hallo")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 429 || apiErr.RetryAfter != 60*time.Second {
+		t.Fatalf("err = %v, want 429 with retry_after 60s", err)
+	}
+	if n := transport.count("sendMessage"); n != 1 {
+		t.Errorf("sendMessage attempts = %d, want 1 (retry_after > %s)", n, maxRetryAfter)
+	}
+}
+
+// 1ms, 2ms, 4ms ...
+func TestRetryDelayBacksOffExponentially(t *testing.T) {
+	_, bot, _ := newRetryClient(t)
+	err := &APIError{Method: "sendMessage", StatusCode: 503}
+
+	for attempt := 1; attempt < 4; attempt++ {
+		want := time.Millisecond << (attempt - 1)
+		wait, retry := bot.retryDelay(t.Context(), err, attempt)
+		if !retry || wait != want {
+			t.Errorf("attempt %d: wait %s, retry %v; want %s, true", attempt, wait, retry, want)
+		}
+	}
+	if _, retry := bot.retryDelay(t.Context(), err, 4); retry {
+		t.Error("retried after MaxAttempts")
+	}
+}
+
+func TestMarkdownErrorResendsPlain(t *testing.T) {
+	srv, bot, transport := newRetryClient(t)
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 400, Description: "Bad Request: can't parse entities: Can't find end of the entity starting at byte offset 3"})
+
+	if err := bot.SendMessage("42", "der_Tisch"); err != nil {
+		t.Fatal(err)
+	}
+	if n := transport.count("sendMessage"); n != 2 {
+		t.Errorf("sendMessage attempts = %d, want 2", n)
+	}
+	sent := srv.SentTo("42")
+	if len(sent) != 1 || sent[0].Text != "der_Tisch" || sent[0].ParseMode != "" {
+		t.Errorf("sent = %+v, want one plain message without parse_mode", sent)
+	}
+
+	// 두 번째도 실패하면 더 보내지 않음
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 400, Description: "Bad Request: can't parse entities"})
+	srv.FailNext("sendMessage", telegramtest.Failure{Code: 400, Description: "Bad Request: can't parse entities"})
+	if err := bot.SendMessage("42", "der_Tisch"); !isMarkdownError(err) {
+		t.Errorf("err = %v, want the markdown error", err)
+	}
+	if n := transport.count("sendMessage"); n != 4 {
+		t.Errorf("sendMessage attempts = %d, want 4", n)
+	}
+}
@@ -0,0 +1,451 @@
+// Package telegramtest provides an in-process fake of the Telegram Bot API
+// for offline end-to-end tests, in the spirit of net/http/httptest.
+//
+// Point a client at Server.URL, script incoming messages with AddMessage and
+// inspect what the bot replied with Sent or SentTo.
+package telegramtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SentMessage is a sendMessage or editMessageText call recorded by the fake
+// server.
+type SentMessage struct {
+	ChatID    string
+	MessageID int
+	Text      string
+	ParseMode string
+	Keyboard  [][]Button
+}
+
+// Button is an inline keyboard button attached to a sent message.
+type Button struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+// CallbackAnswer is an answerCallbackQuery call.
+type CallbackAnswer struct {
+	CallbackID string
+	Text       string
+}
+
+// Failure is an error response returned in place of a method call, scripted
+// with FailNext.
+type Failure struct {
+	Code        int    // HTTP status and error_code, e.g. 429
+	Description string // e.g. "Too Many Requests: retry after 1"
+	RetryAfter  int    // seconds, sent as parameters.retry_after when > 0
+}
+
+// Server is a fake Bot API server. Updates are served from an in-memory queue
+// with the same offset semantics as getUpdates: requesting offset N confirms
+// and drops every update with a smaller ID.
+type Server struct {
+	*httptest.Server
+	Token string
+
+	mu           sync.Mutex
+	updates      []map[string]interface{}
+	nextUpdateID int
+	nextMsgID    int
+	sent         []SentMessage
+	edits        []SentMessage
+	answers      []CallbackAnswer
+	webhookURL   string
+	languages    map[int64]string
+	failures     map[string][]Failure
+	blocked      map[string]bool
+	notify       chan struct{}
+}
+
+// NewServer starts a fake Bot API server that accepts requests for token.
+// The caller must call Close when done.
+func NewServer(token string) *Server {
+	s := &Server{
+		Token:        token,
+		nextUpdateID: 1,
+		nextMsgID:    1,
+		languages:    make(map[int64]string),
+		failures:     make(map[string][]Failure),
+		blocked:      make(map[string]bool),
+		notify:       make(chan struct{}),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// AddMessage queues a text message from chatID and returns its update_id.
+func (s *Server) AddMessage(chatID int64, text string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextUpdateID
+	s.nextUpdateID++
+	s.updates = append(s.updates, map[string]interface{}{
+		"update_id": id,
+		"message": map[string]interface{}{
+			"message_id": s.newMessageID(),
+			"date":       time.Now().Unix(),
+			"chat":       map[string]interface{}{"id": chatID, "type": "private"},
+			"from":       s.from(chatID),
+			"text":       text,
+		},
+	})
+
+	s.wake()
+	return id
+}
+
+// AddCallback queues a press of the inline button carrying data on message
+// messageID in chatID, and returns its update_id.
+func (s *Server) AddCallback(chatID int64, messageID int, data string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextUpdateID
+	s.nextUpdateID++
+	s.updates = append(s.updates, map[string]interface{}{
+		"update_id": id,
+		"callback_query": map[string]interface{}{
+			"id":   strconv.Itoa(id),
+			"from": s.from(chatID),
+			"message": map[string]interface{}{
+				"message_id": messageID,
+				"chat":       map[string]interface{}{"id": chatID, "type": "private"},
+			},
+			"data": data,
+		},
+	})
+
+	s.wake()
+	return id
+}
+
+// SetLanguageCode sets the language_code reported for the user in chatID on
+// updates queued after the call. An empty code omits the field.
+func (s *Server) SetLanguageCode(chatID int64, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.languages[chatID] = code
+}
+
+// from returns the sender of an update from chatID. Caller holds mu.
+func (s *Server) from(chatID int64) map[string]interface{} {
+	user := map[string]interface{}{"id": chatID, "is_bot": false, "first_name": "Test"}
+	if code := s.languages[chatID]; code != "" {
+		user["language_code"] = code
+	}
+	return user
+}
+
+// FailNext makes the next call to method (e.g. "sendMessage") fail with f
+// instead of being handled. Calls to FailNext queue up, one failure per call.
+func (s *Server) FailNext(method string, f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[method] = append(s.failures[method], f)
+}
+
+// SetBlocked makes sendMessage and editMessageText to chatID fail with 403
+// "Forbidden: bot was blocked by the user" until it is called again with
+// blocked false.
+func (s *Server) SetBlocked(chatID int64, blocked bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blocked[strconv.FormatInt(chatID, 10)] = blocked
+}
+
+// nextFailure pops the next scripted failure for method, if any.
+func (s *Server) nextFailure(method string) (Failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queue := s.failures[method]
+	if len(queue) == 0 {
+		return Failure{}, false
+	}
+	s.failures[method] = queue[1:]
+	return queue[0], true
+}
+
+// wake wakes up any long-polling getUpdates. Caller holds mu.
+func (s *Server) wake() {
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// Sent returns every message the bot has sent so far.
+func (s *Server) Sent() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentMessage(nil), s.sent...)
+}
+
+// SentTo returns the messages sent to chatID.
+func (s *Server) SentTo(chatID string) []SentMessage {
+	var result []SentMessage
+	for _, m := range s.Sent() {
+		if m.ChatID == chatID {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Edits returns every editMessageText call so far.
+func (s *Server) Edits() []SentMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentMessage(nil), s.edits...)
+}
+
+// CallbackAnswers returns every answerCallbackQuery call so far.
+func (s *Server) CallbackAnswers() []CallbackAnswer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]CallbackAnswer(nil), s.answers...)
+}
+
+// Reset forgets recorded messages, edits and callback answers, keeping queued
+// updates.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = nil
+	s.edits = nil
+	s.answers = nil
+}
+
+// WebhookURL returns the URL registered through setWebhook.
+func (s *Server) WebhookURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.webhookURL
+}
+
+func (s *Server) newMessageID() int {
+	id := s.nextMsgID
+	s.nextMsgID++
+	return id
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	prefix := "/bot" + s.Token + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if err := parseParams(r); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: "+err.Error())
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, prefix)
+	if f, ok := s.nextFailure(method); ok {
+		writeFailure(w, f)
+		return
+	}
+
+	switch method {
+	case "getUpdates":
+		s.getUpdates(w, r)
+	case "sendMessage":
+		s.sendMessage(w, r)
+	case "editMessageText":
+		s.editMessageText(w, r)
+	case "answerCallbackQuery":
+		s.mu.Lock()
+		s.answers = append(s.answers, CallbackAnswer{CallbackID: r.Form.Get("callback_query_id"), Text: r.Form.Get("text")})
+		s.mu.Unlock()
+		writeResult(w, true)
+	case "setWebhook":
+		s.mu.Lock()
+		s.webhookURL = r.Form.Get("url")
+		s.mu.Unlock()
+		writeResult(w, true)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found: method "+method+" not found")
+	}
+}
+
+func (s *Server) getUpdates(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.Form.Get("offset"))
+	timeout, _ := strconv.Atoi(r.Form.Get("timeout"))
+	deadline := time.After(time.Duration(timeout) * time.Second)
+
+	for {
+		s.mu.Lock()
+		pending := s.confirm(offset)
+		notify := s.notify
+		s.mu.Unlock()
+
+		if len(pending) > 0 || timeout <= 0 {
+			writeResult(w, pending)
+			return
+		}
+
+		select {
+		case <-notify:
+		case <-deadline:
+			writeResult(w, []interface{}{})
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// confirm drops updates below offset and returns the rest. Caller holds mu.
+func (s *Server) confirm(offset int) []map[string]interface{} {
+	kept := s.updates[:0]
+	for _, u := range s.updates {
+		if u["update_id"].(int) >= offset {
+			kept = append(kept, u)
+		}
+	}
+	s.updates = kept
+	return append([]map[string]interface{}(nil), kept...)
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	chatID := r.Form.Get("chat_id")
+	text := r.Form.Get("text")
+	if chatID == "" || text == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat_id and text are required")
+		return
+	}
+
+	keyboard, err := parseKeyboard(r.Form.Get("reply_markup"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: can't parse reply keyboard markup JSON object")
+		return
+	}
+
+	s.mu.Lock()
+	if s.blocked[chatID] {
+		s.mu.Unlock()
+		writeError(w, http.StatusForbidden, "Forbidden: bot was blocked by the user")
+		return
+	}
+	messageID := s.newMessageID()
+	s.sent = append(s.sent, SentMessage{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: r.Form.Get("parse_mode"),
+		Keyboard:  keyboard,
+	})
+	s.mu.Unlock()
+
+	id, _ := strconv.ParseInt(chatID, 10, 64)
+	writeResult(w, map[string]interface{}{
+		"message_id": messageID,
+		"date":       time.Now().Unix(),
+		"chat":       map[string]interface{}{"id": id, "type": "private"},
+		"text":       text,
+	})
+}
+
+func (s *Server) editMessageText(w http.ResponseWriter, r *http.Request) {
+	chatID := r.Form.Get("chat_id")
+	messageID, _ := strconv.Atoi(r.Form.Get("message_id"))
+	text := r.Form.Get("text")
+	if chatID == "" || messageID == 0 || text == "" {
+		writeError(w, http.StatusBadRequest, "Bad Request: chat_id, message_id and text are required")
+		return
+	}
+
+	keyboard, err := parseKeyboard(r.Form.Get("reply_markup"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request: can't parse reply keyboard markup JSON object")
+		return
+	}
+
+	s.mu.Lock()
+	if s.blocked[chatID] {
+		s.mu.Unlock()
+		writeError(w, http.StatusForbidden, "Forbidden: bot was blocked by the user")
+		return
+	}
+	s.edits = append(s.edits, SentMessage{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: r.Form.Get("parse_mode"),
+		Keyboard:  keyboard,
+	})
+	s.mu.Unlock()
+
+	writeResult(w, true)
+}
+
+func parseKeyboard(markup string) ([][]Button, error) {
+	if markup == "" {
+		return nil, nil
+	}
+
+	var parsed struct {
+		InlineKeyboard [][]Button `json:"inline_keyboard"`
+	}
+	if err := json.Unmarshal([]byte(markup), &parsed); err != nil {
+		return nil, err
+	}
+	return parsed.InlineKeyboard, nil
+}
+
+// parseParams accepts both form-encoded and JSON bodies, like the real API.
+func parseParams(r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return err
+		}
+		r.Form = make(map[string][]string)
+		for k, v := range body {
+			switch v := v.(type) {
+			case string:
+				r.Form.Set(k, v)
+			default:
+				raw, _ := json.Marshal(v)
+				r.Form.Set(k, string(raw))
+			}
+		}
+		return nil
+	}
+	return r.ParseForm()
+}
+
+func writeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+func writeError(w http.ResponseWriter, code int, description string) {
+	writeFailure(w, Failure{Code: code, Description: description})
+}
+
+func writeFailure(w http.ResponseWriter, f Failure) {
+	body := map[string]interface{}{
+		"ok":          false,
+		"error_code":  f.Code,
+		"description": f.Description,
+	}
+	if f.RetryAfter > 0 {
+		body["parameters"] = map[string]interface{}{"retry_after": f.RetryAfter}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(f.Code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ---------------- 주제별 단어 ----------------
+// 주제 표시 이름 (카탈로그의 topic.<태그>, 없는 주제는 태그 그대로 표시)
+func topicLabel(topic, locale string) string {
+	return trOr(locale, "topic."+topic, trOr(defaultLocale, "topic."+topic, topic))
+}
+
+// 수업 제목 (A1 Level, 💻 IT, 🏥 건강 · B1)
+func lessonLabel(level, topic, locale string) string {
+	switch {
+	case topic == "":
+		return tr(locale, "lesson.level_label", "level", levelName(level))
+	case level == "":
+		return topicLabel(topic, locale)
+	}
+	return topicLabel(topic, locale) + " · " + levelName(level)
+}
+
+func isTopic(name string) bool {
+	return len(vocab.TopicWords(name, "")) > 0
+}
+
+// ---------------- /topics ----------------
+func handleTopicsCommand(bot Messenger, chatID string) {
+	locale := chatLocale(chatID)
+	counts := vocab.TopicCounts()
+	if len(counts) == 0 {
+		sendToTelegram(bot, chatID, tr(locale, "topics.none"))
+		return
+	}
+
+	topics := make([]string, 0, len(counts))
+	for t := range counts {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+
+	msg := tr(locale, "topics.title") + "\n\n"
+	for _, t := range topics {
+		total := 0
+		var levels []string
+		for _, level := range levelIDs() {
+			if n := counts[t][level]; n > 0 {
+				total += n
+				levels = append(levels, fmt.Sprintf("%s %d", levelName(level), n))
+			}
+		}
+		msg += tr(locale, "topics.line", "label", topicLabel(t, locale), "topic", t, "count", total, "levels", strings.Join(levels, " · ")) + "\n\n"
+	}
+	msg += tr(locale, "topics.example")
+
+	sendToTelegram(bot, chatID, msg)
+}
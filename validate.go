@@ -0,0 +1,267 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ---------------- 단어장 검사 (go run . validate) ----------------
+// 검사에서 찾은 문제 하나
+type vocabIssue struct {
+	Severity string `json:"severity"` // error, warning
+	Check    string `json:"check"`
+	File     string `json:"file"`
+	Index    int    `json:"index"` // 파일 안 위치 (0부터), 파일 전체 문제는 -1
+	Word     string `json:"word,omitempty"`
+	Message  string `json:"message"`
+}
+
+type vocabReport struct {
+	Errors   int          `json:"errors"`
+	Warnings int          `json:"warnings"`
+	Issues   []vocabIssue `json:"issues"`
+}
+
+func (r *vocabReport) add(severity, check, file string, index int, word, format string, args ...any) {
+	r.Issues = append(r.Issues, vocabIssue{
+		Severity: severity,
+		Check:    check,
+		File:     file,
+		Index:    index,
+		Word:     word,
+		Message:  fmt.Sprintf(format, args...),
+	})
+	if severity == "error" {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// 품사/성 표기 ("Adjektiv / Adverb"처럼 /로 여러 개 가능)
+var knownGenders = map[string]bool{
+	"Maskulin": true, "Feminin": true, "Neutrum": true, "Plural": true,
+	"Verb": true, "Adjektiv": true, "Adverb": true, "Pronomen": true,
+	"Possessivpronomen": true, "Präposition": true, "Konjunktion": true,
+}
+
+// 명사 gender → 표제어에 붙어야 하는 관사
+var genderArticles = map[string]string{
+	"Maskulin": "der",
+	"Feminin":  "die",
+	"Neutrum":  "das",
+	"Plural":   "die",
+}
+
+// go run . validate [-json] [-strict]
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "JSON으로 출력")
+	strict := fs.Bool("strict", false, "경고도 실패로 처리")
+	fs.Parse(args)
+
+	report := validateVocabulary()
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		printVocabReport(report)
+	}
+
+	if report.Errors > 0 || (*strict && report.Warnings > 0) {
+		os.Exit(1)
+	}
+}
+
+func validateVocabulary() vocabReport {
+	report := vocabReport{Issues: []vocabIssue{}}
+
+	// 표제어 → 처음 나온 레벨 파일/위치
+	type seenWord struct {
+		level string
+		file  string
+		index int
+	}
+	seen := make(map[string]seenWord)
+	levelSeen := make(map[string]bool)
+
+	// 매니페스트에 문제가 있으면 기본 레벨로 단어 파일은 계속 검사
+	levels, err := readLevels()
+	if err != nil {
+		report.add("error", "invalid-levels", levelsFile, -1, "", "레벨 목록을 읽을 수 없음 (기본 레벨로 검사): %v", err)
+	}
+
+	for _, level := range levels {
+		if levelSeen[level.ID] {
+			report.add("error", "duplicate-level", levelsFile, -1, "", "레벨 id %q 가 두 번 등록됨", level.ID)
+			continue
+		}
+		levelSeen[level.ID] = true
+
+		file := filepath.Join(vocabularyDir, level.File)
+		data, err := os.ReadFile(file)
+		if err != nil {
+			report.add("error", "missing-file", file, -1, "", "%s 레벨 단어 파일을 읽을 수 없음: %v", level.Name, err)
+			continue
+		}
+
+		var words []Word
+		if err := json.Unmarshal(data, &words); err != nil {
+			report.add("error", "invalid-json", file, -1, "", "JSON 파싱 실패: %v", err)
+			continue
+		}
+		if len(words) == 0 {
+			report.add("error", "empty-file", file, -1, "", "%s 레벨에 단어가 없음", level.Name)
+		}
+
+		for i, w := range words {
+			key := foldGerman(strings.TrimSpace(w.German))
+			if key == "" {
+				report.add("error", "empty-headword", file, i, "", "표제어(german)가 비어 있음")
+				continue
+			}
+
+			if first, ok := seen[key]; ok {
+				if first.level == level.ID {
+					report.add("error", "duplicate", file, i, w.German, "같은 레벨에 이미 있음 (#%d)", first.index)
+				} else {
+					report.add("warning", "duplicate-across-levels", file, i, w.German,
+						"%s 레벨에도 있음 (%s #%d), /learned 기록이 한 레벨로만 들어감", levelName(first.level), first.file, first.index)
+				}
+			} else {
+				seen[key] = seenWord{level.ID, file, i}
+			}
+
+			validateWord(&report, file, i, w, level)
+		}
+	}
+
+	validateSentences(&report)
+	return report
+}
+
+func validateWord(report *vocabReport, file string, i int, w Word, level Level) {
+	if !strings.EqualFold(w.Level, level.Name) && !strings.EqualFold(w.Level, level.ID) {
+		report.add("error", "level-mismatch", file, i, w.German, "level 값 %q 가 파일 레벨 %s 와 다름", w.Level, level.Name)
+	}
+
+	// 성/품사
+	var parts []string
+	for _, p := range strings.Split(w.Gender, "/") {
+		parts = append(parts, strings.TrimSpace(p))
+	}
+	for _, p := range parts {
+		if !knownGenders[p] {
+			report.add("warning", "unknown-gender", file, i, w.German, "알 수 없는 gender 값 %q", w.Gender)
+			break
+		}
+	}
+
+	// 명사는 관사가 붙어 있어야 /artikel 에 나옴
+	if len(parts) == 1 {
+		if want, isNoun := genderArticles[parts[0]]; isNoun {
+			article, _, _ := strings.Cut(strings.ToLower(w.German), " ")
+			switch article {
+			case want:
+			case "der", "die", "das":
+				report.add("warning", "article-mismatch", file, i, w.German, "%s 명사인데 관사가 %q 가 아님", parts[0], want)
+			default:
+				report.add("warning", "missing-article", file, i, w.German, "%s 명사인데 표제어에 관사 %q 가 없음", parts[0], want)
+			}
+		}
+	}
+
+	// 예문
+	if len(w.Examples) == 0 {
+		report.add("error", "empty-examples", file, i, w.German, "예문이 없음")
+	}
+	lemma := clozeLemma(w.German)
+	for j, ex := range w.Examples {
+		if strings.TrimSpace(ex) == "" {
+			report.add("error", "empty-examples", file, i, w.German, "%d번째 예문이 비어 있음", j+1)
+			continue
+		}
+		if _, ok := findInflectedForm(lemma, ex); !ok {
+			report.add("warning", "example-without-headword", file, i, w.German, "%d번째 예문에 표제어가 없음: %s", j+1, ex)
+		}
+	}
+
+	// 동의어
+	headword := matchKey(w.German)
+	synonyms := make(map[string]bool)
+	for _, s := range w.Synonyms {
+		key := matchKey(s)
+		switch {
+		case key == "":
+			report.add("warning", "malformed-synonym", file, i, w.German, "빈 동의어")
+		case s != strings.TrimSpace(s):
+			report.add("warning", "malformed-synonym", file, i, w.German, "동의어 %q 앞뒤에 공백이 있음", s)
+		case strings.ContainsAny(s, ",;"):
+			report.add("warning", "malformed-synonym", file, i, w.German, "동의어 %q 는 여러 단어를 한 항목에 넣음", s)
+		case key == headword:
+			report.add("warning", "malformed-synonym", file, i, w.German, "동의어 %q 가 표제어와 같음", s)
+		case synonyms[key]:
+			report.add("warning", "malformed-synonym", file, i, w.German, "동의어 %q 가 중복됨", s)
+		}
+		synonyms[key] = true
+	}
+}
+
+func validateSentences(report *vocabReport) {
+	const file = "vocabulary/sentences.json"
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		report.add("error", "missing-file", file, -1, "", "명언 파일을 읽을 수 없음: %v", err)
+		return
+	}
+
+	var sentences []WiseSentences
+	if err := json.Unmarshal(data, &sentences); err != nil {
+		report.add("error", "invalid-json", file, -1, "", "JSON 파싱 실패: %v", err)
+		return
+	}
+	if len(sentences) == 0 {
+		report.add("error", "empty-sentences", file, -1, "", "명언이 하나도 없음")
+	}
+	for i, s := range sentences {
+		if strings.TrimSpace(s.German) == "" || strings.TrimSpace(s.English) == "" {
+			report.add("warning", "empty-sentences", file, i, s.German, "german/english 중 비어 있는 값이 있음")
+		}
+	}
+}
+
+func printVocabReport(report vocabReport) {
+	counts := make(map[string]int)
+	for _, issue := range report.Issues {
+		counts[issue.Check]++
+
+		location := issue.File
+		if issue.Index >= 0 {
+			location = fmt.Sprintf("%s #%d", issue.File, issue.Index)
+		}
+		if issue.Word != "" {
+			location += " (" + issue.Word + ")"
+		}
+		fmt.Printf("%s: %s [%s] %s\n", issue.Severity, location, issue.Check, issue.Message)
+	}
+
+	checks := make([]string, 0, len(counts))
+	for check := range counts {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+
+	fmt.Println()
+	for _, check := range checks {
+		fmt.Printf("  %-26s %d\n", check, counts[check])
+	}
+	fmt.Printf("✓ Checked vocabulary: %d errors, %d warnings\n", report.Errors, report.Warnings)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// levels.json 문제는 기본 레벨로 넘어가지 않고 검사 오류로 보고
+func TestValidateReportsBrokenLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string // "" 이면 파일 없음
+	}{
+		{"missing", ""},
+		{"invalid json", `[{"id": "a1",`},
+		{"empty list", `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.Mkdir(vocabularyDir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if tt.manifest != "" {
+				if err := os.WriteFile(levelsFile, []byte(tt.manifest), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			report := validateVocabulary()
+			for _, issue := range report.Issues {
+				if issue.Check == "invalid-levels" && issue.Severity == "error" && issue.File == levelsFile {
+					return
+				}
+			}
+			t.Errorf("no invalid-levels error in %+v", report.Issues)
+		})
+	}
+}
+
+func TestValidateAcceptsLevels(t *testing.T) {
+	for _, issue := range validateVocabulary().Issues {
+		if issue.Check == "invalid-levels" {
+			t.Errorf("vocabulary/levels.json: %s", issue.Message)
+		}
+	}
+}
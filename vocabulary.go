@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ---------------- 단어장 인덱스 ----------------
+// 실행마다 한 번 읽어서 메모리에 두는 단어장 (명령어마다 파일을 다시 읽지 않음)
+// 로드 후에는 읽기만 하므로 여러 goroutine에서 같이 써도 됨
+type Vocabulary struct {
+	Levels    []Level
+	Sentences []WiseSentences
+
+	byLevel    map[string][]Word // 레벨 id → 파일 순서대로 (Word.Level = 레벨 id)
+	byHeadword map[string][]Word // 표제어 그대로 → 단어 (여러 레벨에 있을 수 있음)
+	byKey      map[string][]string
+	byGender   map[string][]Word // gender → 단어 (철자 변형은 맞춰서, "Adjektiv / Adverb"는 둘 다에)
+	byTopic    map[string][]Word
+	headwords  []string // 중복 없는 표제어 (오타 허용 검색용)
+}
+
+// 단어장 (main에서 한 번 로드)
+var vocab *Vocabulary
+
+func loadVocabulary() (*Vocabulary, error) {
+	levels, err := readLevels()
+	if err != nil {
+		fmt.Printf("⚠️ Using default levels: %v\n", err)
+	}
+
+	v := &Vocabulary{
+		Levels:     levels,
+		byLevel:    make(map[string][]Word),
+		byHeadword: make(map[string][]Word),
+		byKey:      make(map[string][]string),
+		byGender:   make(map[string][]Word),
+		byTopic:    make(map[string][]Word),
+	}
+
+	for _, level := range v.Levels {
+		data, err := os.ReadFile(filepath.Join(vocabularyDir, level.File))
+		if err != nil {
+			return nil, err
+		}
+
+		var words []Word
+		if err := json.Unmarshal(data, &words); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", level.File, err)
+		}
+		// 파일마다 level 표기가 다를 수 있어서 레벨 id로 맞춤
+		for i := range words {
+			words[i].Level = level.ID
+			v.add(words[i])
+		}
+		v.byLevel[level.ID] = words
+	}
+
+	// 명언은 없어도 수업은 보냄
+	data, _ := os.ReadFile(filepath.Join(vocabularyDir, "sentences.json"))
+	json.Unmarshal(data, &v.Sentences)
+
+	return v, nil
+}
+
+func (v *Vocabulary) add(w Word) {
+	if _, seen := v.byHeadword[w.German]; !seen {
+		v.headwords = append(v.headwords, w.German)
+		key := matchKey(w.German)
+		v.byKey[key] = append(v.byKey[key], w.German)
+	}
+	v.byHeadword[w.German] = append(v.byHeadword[w.German], w)
+
+	for _, g := range strings.Split(w.Gender, "/") {
+		if g = normalizeGender(g); g != "" {
+			v.byGender[g] = append(v.byGender[g], w)
+		}
+	}
+	for _, t := range w.Topics {
+		t = strings.ToLower(t)
+		v.byTopic[t] = append(v.byTopic[t], w)
+	}
+}
+
+// 레벨 단어 (파일 순서, 수정하지 말 것)
+func (v *Vocabulary) LevelWords(level string) ([]Word, error) {
+	words, ok := v.byLevel[strings.ToLower(level)]
+	if !ok {
+		return nil, fmt.Errorf("unknown level %q", level)
+	}
+	return words, nil
+}
+
+// 표제어의 레벨 (여러 레벨에 있으면 앞 레벨)
+func (v *Vocabulary) LevelOf(german string) (string, bool) {
+	words := v.byHeadword[german]
+	if len(words) == 0 {
+		return "", false
+	}
+	return words[0].Level, true
+}
+
+func (v *Vocabulary) Find(level, german string) (Word, bool) {
+	for _, w := range v.byHeadword[german] {
+		if w.Level == strings.ToLower(level) {
+			return w, true
+		}
+	}
+	return Word{}, false
+}
+
+// 입력과 일치하는 표제어 후보 (matchWords와 같은 순서)
+// 관사를 뺀 키가 같은 단어가 있으면 그 안에서만 찾고, 없을 때만 전체에서 오타 허용 검색
+func (v *Vocabulary) Match(input string) []string {
+	if candidates := v.byKey[matchKey(input)]; len(candidates) > 0 {
+		return matchWords(input, candidates)
+	}
+	return matchWords(input, v.headwords)
+}
+
+// 단어 파일에 있는 gender 철자 변형
+var genderAliases = map[string]string{
+	"Maskullin": "Maskulin",
+	"Neutral":   "Neutrum",
+}
+
+func normalizeGender(g string) string {
+	g = strings.TrimSpace(g)
+	if alias, ok := genderAliases[g]; ok {
+		return alias
+	}
+	return g
+}
+
+// gender 값별 단어 (level이 비어 있으면 모든 레벨에서)
+func (v *Vocabulary) GenderWords(gender, level string) []Word {
+	words := v.byGender[normalizeGender(gender)]
+	if level == "" {
+		return words
+	}
+
+	var result []Word
+	for _, w := range words {
+		if w.Level == strings.ToLower(level) {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// 주제 단어 (level이 비어 있으면 모든 레벨에서)
+func (v *Vocabulary) TopicWords(topic, level string) []Word {
+	words := v.byTopic[strings.ToLower(topic)]
+	if level == "" {
+		return words
+	}
+
+	var result []Word
+	for _, w := range words {
+		if w.Level == strings.ToLower(level) {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// 주제 → 레벨 → 단어 수
+func (v *Vocabulary) TopicCounts() map[string]map[string]int {
+	counts := make(map[string]map[string]int)
+	for topic, words := range v.byTopic {
+		counts[topic] = make(map[string]int)
+		for _, w := range words {
+			counts[topic][w.Level]++
+		}
+	}
+	return counts
+}
+
+func (v *Vocabulary) RandomSentence() (WiseSentences, bool) {
+	if len(v.Sentences) == 0 {
+		return WiseSentences{}, false
+	}
+	return v.Sentences[rand.Intn(len(v.Sentences))], true
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 인덱스 이전 방식: 명령어마다 레벨 파일을 전부 읽고 파싱
+func parseLevelFiles(b *testing.B) map[string][]Word {
+	b.Helper()
+
+	words := make(map[string][]Word)
+	for _, level := range loadLevels() {
+		data, err := os.ReadFile(filepath.Join(vocabularyDir, level.File))
+		if err != nil {
+			b.Fatal(err)
+		}
+		var levelWords []Word
+		if err := json.Unmarshal(data, &levelWords); err != nil {
+			b.Fatal(err)
+		}
+		words[level.ID] = levelWords
+	}
+	return words
+}
+
+func loadBenchVocabulary(b *testing.B) {
+	b.Helper()
+
+	var err error
+	if vocab, err = loadVocabulary(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+}
+
+// /stats 레벨별 단어 수
+func BenchmarkStatsParseFiles(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		total := 0
+		for _, words := range parseLevelFiles(b) {
+			total += len(words)
+		}
+	}
+}
+
+func BenchmarkStatsIndex(b *testing.B) {
+	loadBenchVocabulary(b)
+	for i := 0; i < b.N; i++ {
+		total := 0
+		for _, level := range registeredLevels() {
+			words, _ := vocab.LevelWords(level.ID)
+			total += len(words)
+		}
+	}
+}
+
+// /learned 단어 찾기 (정확히 일치 + 오타)
+func BenchmarkLearnedParseFiles(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var headwords []string
+		for _, words := range parseLevelFiles(b) {
+			for _, w := range words {
+				headwords = append(headwords, w.German)
+			}
+		}
+		matchWords("Haus", headwords)
+		matchWords("Verspatung", headwords)
+	}
+}
+
+func BenchmarkLearnedIndex(b *testing.B) {
+	loadBenchVocabulary(b)
+	for i := 0; i < b.N; i++ {
+		vocab.Match("Haus")
+		vocab.Match("Verspatung")
+	}
+}
+
+// 시작할 때 한 번 드는 비용
+func BenchmarkLoadVocabulary(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := loadVocabulary(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// a2는 "Neutral", b1은 "Maskullin"으로 적혀 있어도 같은 gender로 색인
+func TestGenderWordsNormalizesSpelling(t *testing.T) {
+	v, err := loadVocabulary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct{ gender, level string }{{"Neutrum", "a2"}, {"Maskulin", "b1"}} {
+		words := v.GenderWords(tt.gender, tt.level)
+		if len(words) == 0 {
+			t.Errorf("GenderWords(%q, %q) is empty", tt.gender, tt.level)
+		}
+		for _, w := range words {
+			if w.Level != tt.level {
+				t.Errorf("GenderWords(%q, %q) has %s word %s", tt.gender, tt.level, w.Level, w.German)
+			}
+		}
+	}
+
+	if len(v.GenderWords("Neutral", "")) != len(v.GenderWords("Neutrum", "")) {
+		t.Error("GenderWords(Neutral) differs from GenderWords(Neutrum)")
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// 웹훅 요청과 스케줄러가 동시에 진행도 파일을 건드리지 않도록 직렬화
+var updateMu sync.Mutex
+
+// ---------------- 웹훅 서버 모드 ----------------
+// WEBHOOK_URL    : 텔레그램에 등록할 공개 URL (예: https://bot.example.com/telegram)
+// WEBHOOK_SECRET : X-Telegram-Bot-Api-Secret-Token 값
+// WEBHOOK_ADDR   : 리슨 주소 (기본 :8080)
+func serveWebhook(bot *TelegramClient) {
+	webhookURL := os.Getenv("WEBHOOK_URL")
+	secret := os.Getenv("WEBHOOK_SECRET")
+	addr := os.Getenv("WEBHOOK_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	if webhookURL == "" || secret == "" {
+		fmt.Println("Error: WEBHOOK_URL and WEBHOOK_SECRET must be set")
+		return
+	}
+
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		fmt.Println("Error: invalid WEBHOOK_URL:", err)
+		return
+	}
+	path := parsed.Path
+	if path == "" {
+		path = "/"
+	}
+
+	if err := bot.SetWebhook(webhookURL, secret); err != nil {
+		fmt.Println("Error registering webhook:", err)
+		return
+	}
+	fmt.Println("✓ Webhook registered:", webhookURL)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle(path, newWebhookHandler(bot, secret))
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go runScheduler(ctx, bot)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("✓ Webhook server listening on %s%s\n", addr, path)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Println("Error running webhook server:", err)
+	}
+	fmt.Println("✓ Webhook server stopped")
+}
+
+// 텔레그램이 POST하는 Update JSON을 받아 polling과 같은 핸들러로 처리
+// 웹훅은 offset이 필요 없고, 업데이트가 일주일 넘게 없으면 update_id가 새로 정해지므로
+// bot_state.json의 offset 대신 최근에 처리한 update_id로 재전송만 거름
+func newWebhookHandler(bot Messenger, secret string) http.Handler {
+	seen := newRecentUpdates(recentUpdateLimit)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		var update Update
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&update); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		updateMu.Lock()
+		if seen.add(update.UpdateID) {
+			dispatchUpdate(bot, update)
+		}
+		updateMu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// 기억해 둘 최근 update_id 수 (텔레그램은 응답을 못 받은 업데이트를 다시 보냄)
+const recentUpdateLimit = 1000
+
+// 최근에 처리한 update_id (오래된 것부터 잊음)
+type recentUpdates struct {
+	ids   map[int]bool
+	order []int
+	limit int
+}
+
+func newRecentUpdates(limit int) *recentUpdates {
+	return &recentUpdates{ids: make(map[int]bool), limit: limit}
+}
+
+// 처음 보는 update_id면 기록하고 true
+func (r *recentUpdates) add(id int) bool {
+	if r.ids[id] {
+		return false
+	}
+	r.ids[id] = true
+	r.order = append(r.order, id)
+	if len(r.order) > r.limit {
+		delete(r.ids, r.order[0])
+		r.order = r.order[1:]
+	}
+	return true
+}
+
+// 웹훅 모드용 스케줄러: 1분마다 예약 작업 실행
+func runScheduler(ctx context.Context, bot Messenger) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		updateMu.Lock()
+		runScheduledJobs(bot)
+		updateMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postUpdate(t *testing.T, h http.Handler, secret, body string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/telegram", strings.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestWebhookRejectsWrongSecret(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	h := newWebhookHandler(bot, "s3cret")
+
+	if code := postUpdate(t, h, "wrong", `{"update_id":1,"message":{"chat":{"id":42},"text":"/start"}}`); code != http.StatusForbidden {
+		t.Errorf("wrong secret: status %d, want 403", code)
+	}
+	if sent := srv.Sent(); len(sent) != 0 {
+		t.Errorf("handled update with wrong secret: %q", texts(sent))
+	}
+}
+
+func TestWebhookSkipsRedeliveredUpdates(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	h := newWebhookHandler(bot, "s3cret")
+
+	start := `{"update_id":500,"message":{"chat":{"id":42},"text":"/start"}}`
+	for i := 0; i < 2; i++ {
+		if code := postUpdate(t, h, "s3cret", start); code != http.StatusOK {
+			t.Fatalf("status %d, want 200", code)
+		}
+	}
+	if sent := srv.SentTo("42"); len(sent) != 1 {
+		t.Errorf("redelivered /start: got %d replies, want 1", len(sent))
+	}
+}
+
+// 조용한 기간 뒤 텔레그램이 update_id를 작은 값으로 다시 정해도 처리
+func TestWebhookIgnoresPollingOffset(t *testing.T) {
+	srv, bot, _ := newTestBot(t)
+	saveBotState(BotState{LastUpdateID: 900000})
+	h := newWebhookHandler(bot, "s3cret")
+
+	postUpdate(t, h, "s3cret", `{"update_id":12,"message":{"chat":{"id":42},"text":"/start"}}`)
+	if sent := srv.SentTo("42"); len(sent) != 1 {
+		t.Errorf("update below the stored offset: got %d replies, want 1", len(sent))
+	}
+	if state := loadBotState(); state.LastUpdateID != 900000 {
+		t.Errorf("webhook changed the polling offset to %d", state.LastUpdateID)
+	}
+}
+
+func TestRecentUpdatesForgetsOldest(t *testing.T) {
+	seen := newRecentUpdates(2)
+	for _, id := range []int{1, 2, 3} {
+		if !seen.add(id) {
+			t.Fatalf("add(%d) = false for a new id", id)
+		}
+	}
+	if seen.add(3) {
+		t.Error("add(3) = true for a recent id")
+	}
+	if !seen.add(1) {
+		t.Error("add(1) = false after it was forgotten")
+	}
+}